@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// AddressKind is the script type of an address, which determines the BIP
+// derivation path wallets use for it
+type AddressKind int
+
+const (
+	P2PKH  AddressKind = iota // legacy, 1... on mainnet, BIP44
+	P2SH                      // nested SegWit P2SH-P2WPKH, 3..., BIP49
+	P2WPKH                    // native SegWit, bc1q..., BIP84
+	P2TR                      // Taproot, bc1p..., BIP86
+)
+
+func (k AddressKind) String() string {
+	switch k {
+	case P2PKH:
+		return "p2pkh"
+	case P2SH:
+		return "p2sh"
+	case P2WPKH:
+		return "p2wpkh"
+	case P2TR:
+		return "p2tr"
+	}
+	return fmt.Sprintf("AddressKind(%d)", int(k))
+}
+
+// Purpose returns the BIP purpose of the derivation path used for addresses
+// of this kind: 44, 49, 84 or 86
+func (k AddressKind) Purpose() uint32 {
+	switch k {
+	case P2SH:
+		return 49
+	case P2WPKH:
+		return 84
+	case P2TR:
+		return 86
+	}
+	return 44
+}
+
+// AddressType decodes addr for the given network and returns its kind. P2SH
+// addresses are assumed to wrap P2WPKH, the only script type derived from a
+// single key by BIP49. Other script types, such as P2WSH, are rejected.
+func AddressType(addr string, params *chaincfg.Params) (AddressKind, error) {
+	decoded, err := btcutil.DecodeAddress(addr, params)
+	if err != nil {
+		return 0, fmt.Errorf("invalid address %q: %v", addr, err)
+	}
+	if !decoded.IsForNet(params) {
+		return 0, fmt.Errorf("address %q is not for network %s", addr, params.Name)
+	}
+
+	switch decoded.(type) {
+	case *btcutil.AddressPubKeyHash:
+		return P2PKH, nil
+	case *btcutil.AddressScriptHash:
+		return P2SH, nil
+	case *btcutil.AddressWitnessPubKeyHash:
+		return P2WPKH, nil
+	case *btcutil.AddressTaproot:
+		return P2TR, nil
+	}
+	return 0, fmt.Errorf("unsupported address type %T for %q", decoded, addr)
+}
+
+// encodeAddress encodes the public key of an extended key as an address of
+// the given kind
+func encodeAddress(key *hdkeychain.ExtendedKey, kind AddressKind, params *chaincfg.Params) (string, error) {
+	switch kind {
+	case P2PKH:
+		return p2pkhAddress(key, params)
+	case P2SH:
+		return p2shP2wpkhAddress(key, params)
+	case P2WPKH:
+		return p2wpkhAddress(key, params)
+	case P2TR:
+		return p2trAddress(key, params)
+	}
+	return "", fmt.Errorf("unsupported address kind %v", kind)
+}
+
+// firstAddresses derives the first receiving address
+// (m/purpose'/coin'/0'/0/0) of a mnemonic on the given network for each of
+// kinds. The expensive seed is computed once and shared by all of them.
+func firstAddresses(mnemonic string, kinds []AddressKind, params *chaincfg.Params) ([]string, error) {
+	if !IsMnemonicValid(mnemonic) {
+		return nil, ErrInvalidMnemonic
+	}
+	seed := newSeed(mnemonic, "")
+	defer Zero(seed)
+
+	addresses := make([]string, len(kinds))
+	for i, kind := range kinds {
+		address, err := btcDeriver{kind: kind, params: params}.Address(seed, []uint32{
+			hdkeychain.HardenedKeyStart + kind.Purpose(),
+			hdkeychain.HardenedKeyStart + params.HDCoinType, // Coin type: 0 for Bitcoin, 1 for testnets
+			hdkeychain.HardenedKeyStart,                     // Account: 0
+			0,                                               // Change: 0 for external chain
+			0,                                               // Address index: 0
+		})
+		if err != nil {
+			return nil, err
+		}
+		addresses[i] = address
+	}
+
+	return addresses, nil
+}
+
+// AllAddressTypes derives the first receiving address (m/purpose'/coin'/0'/0/0)
+// of a mnemonic for every common address type on the given network. The map
+// is keyed by "bip44" (legacy), "bip49" (nested SegWit), "bip84" (native
+// SegWit) and "bip86" (Taproot).
+func AllAddressTypes(mnemonic string, params *chaincfg.Params) (map[string]string, error) {
+	kinds := []AddressKind{P2PKH, P2SH, P2WPKH, P2TR}
+	derived, err := firstAddresses(mnemonic, kinds, params)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make(map[string]string, len(kinds))
+	for i, kind := range kinds {
+		addresses[fmt.Sprintf("bip%d", kind.Purpose())] = derived[i]
+	}
+	return addresses, nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestAddressType(t *testing.T) {
+	tests := []struct {
+		address  string
+		params   *chaincfg.Params
+		expected AddressKind
+		purpose  uint32
+	}{
+		{"1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA", &chaincfg.MainNetParams, P2PKH, 44},
+		{"37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf", &chaincfg.MainNetParams, P2SH, 49},
+		{"bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu", &chaincfg.MainNetParams, P2WPKH, 84},
+		{"bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr", &chaincfg.MainNetParams, P2TR, 86},
+		{"mkpZhYtJu2r87Js3pDiWJDmPte2NRZ8bJV", &chaincfg.TestNet3Params, P2PKH, 44},
+	}
+
+	for _, tt := range tests {
+		kind, err := AddressType(tt.address, tt.params)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.address, err)
+		}
+		if kind != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.address, tt.expected, kind)
+		}
+		if kind.Purpose() != tt.purpose {
+			t.Errorf("%s: expected purpose %d, got %d", tt.address, tt.purpose, kind.Purpose())
+		}
+	}
+}
+
+func TestAddressType_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabB", // bad checksum
+		"mkpZhYtJu2r87Js3pDiWJDmPte2NRZ8bJV", // testnet
+		"bc1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3qccfmv3", // P2WSH
+	}
+
+	for _, address := range tests {
+		if _, err := AddressType(address, &chaincfg.MainNetParams); err == nil {
+			t.Errorf("%q: expected an error", address)
+		}
+	}
+}
+
+func TestFirstAddresses(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	addresses, err := firstAddresses(mnemonic, []AddressKind{P2PKH, P2SH, P2WPKH, P2TR}, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []string{
+		"1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA",
+		"37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf",
+		"bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu",
+		"bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr",
+	}
+	for i := range expected {
+		if addresses[i] != expected[i] {
+			t.Errorf("Expected %s, got %s", expected[i], addresses[i])
+		}
+	}
+}
+
+func TestAllAddressTypes(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	addresses, err := AllAddressTypes(mnemonic, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := map[string]string{
+		"bip44": "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA",
+		"bip49": "37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf",
+		"bip84": "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu",
+		"bip86": "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr",
+	}
+	if len(addresses) != len(expected) {
+		t.Errorf("Expected %d address types, got %v", len(expected), addresses)
+	}
+	for key, want := range expected {
+		if addresses[key] != want {
+			t.Errorf("%s: expected %s, got %s", key, want, addresses[key])
+		}
+	}
+
+	if _, err := AllAddressTypes("invalid mnemonic phrase", &chaincfg.MainNetParams); err == nil {
+		t.Errorf("Expected an error for invalid mnemonic")
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// BalanceChecker reports whether an address has any transaction history. It
+// is injected into ScanWithGapLimit so callers can plug in an Electrum
+// server, an Esplora API or a mock.
+type BalanceChecker interface {
+	HasActivity(address string) (bool, error)
+}
+
+// ScanWithGapLimit walks the BIP44 receiving addresses (m/44'/0'/0'/0/i) of a
+// mnemonic in order and returns those with activity, stopping once gap
+// consecutive addresses have none. Wallets use a gap of 20 by default.
+func ScanWithGapLimit(mnemonic string, gap int, checker BalanceChecker) ([]string, error) {
+	d := btcDeriver{kind: P2PKH, params: &chaincfg.MainNetParams}
+	return ScanWithGapLimitWithDeriver(mnemonic, gap, checker, d, externalChain(44, 0))
+}
+
+// ScanWithGapLimitWithDeriver is ScanWithGapLimit for any coin and address
+// type: it walks the addresses chain/i derived with d, e.g. the m/84'/0'/0'/0
+// chain with the "btc-segwit" Deriver.
+func ScanWithGapLimitWithDeriver(mnemonic string, gap int, checker BalanceChecker, d Deriver, chain []uint32) ([]string, error) {
+	if gap <= 0 {
+		return nil, fmt.Errorf("invalid gap limit: %d", gap)
+	}
+	if checker == nil {
+		return nil, fmt.Errorf("no balance checker")
+	}
+
+	if !IsMnemonicValid(mnemonic) {
+		return nil, ErrInvalidMnemonic
+	}
+	seed := newSeed(mnemonic, "")
+	defer Zero(seed)
+
+	var used []string
+	for index, unused := uint32(0), 0; unused < gap; index++ {
+		address, err := d.Address(seed, childPath(chain, index))
+		if err != nil {
+			return used, err
+		}
+
+		active, err := checker.HasActivity(address)
+		if err != nil {
+			return used, fmt.Errorf("failed to check %s: %v", address, err)
+		}
+
+		if active {
+			used = append(used, address)
+			unused = 0
+		} else {
+			unused++
+		}
+	}
+
+	return used, nil
+}
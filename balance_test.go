@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+// mockChecker reports activity for a fixed set of addresses and counts the
+// lookups made
+type mockChecker struct {
+	active  map[string]bool
+	checked int
+	err     error
+}
+
+func (c *mockChecker) HasActivity(address string) (bool, error) {
+	c.checked++
+	if c.err != nil {
+		return false, c.err
+	}
+	return c.active[address], nil
+}
+
+func TestScanWithGapLimit(t *testing.T) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	addresses, err := GenerateBTCAddresses(mnemonic, 6)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Addresses 0 and 3 are used; with a gap of 3 the scan reaches 3 and
+	// stops after 4, 5 and 6 come back unused
+	checker := &mockChecker{active: map[string]bool{addresses[0]: true, addresses[3]: true}}
+	used, err := ScanWithGapLimit(mnemonic, 3, checker)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(used) != 2 || used[0] != addresses[0] || used[1] != addresses[3] {
+		t.Errorf("Expected used addresses %s and %s, got %v", addresses[0], addresses[3], used)
+	}
+	if checker.checked != 7 {
+		t.Errorf("Expected 7 addresses checked, got %d", checker.checked)
+	}
+
+	// A gap smaller than the hole between used addresses misses the later one
+	checker = &mockChecker{active: map[string]bool{addresses[0]: true, addresses[3]: true}}
+	used, err = ScanWithGapLimit(mnemonic, 2, checker)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(used) != 1 {
+		t.Errorf("Expected 1 used address, got %v", used)
+	}
+}
+
+func TestScanWithGapLimit_Errors(t *testing.T) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	if _, err := ScanWithGapLimit(mnemonic, 0, &mockChecker{}); err == nil {
+		t.Errorf("Expected an error for a zero gap")
+	}
+	if _, err := ScanWithGapLimit(mnemonic, 20, nil); err == nil {
+		t.Errorf("Expected an error for a nil checker")
+	}
+	if _, err := ScanWithGapLimit("invalid mnemonic phrase", 20, &mockChecker{}); err == nil {
+		t.Errorf("Expected an error for invalid mnemonic")
+	}
+	if _, err := ScanWithGapLimit(mnemonic, 20, &mockChecker{err: errors.New("connection refused")}); err == nil {
+		t.Errorf("Expected the checker error to be returned")
+	}
+}
+
+func TestScanWithGapLimitWithDeriver(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	d, _ := GetDeriver("btc-segwit")
+
+	checker := &mockChecker{active: map[string]bool{"bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu": true}}
+	used, err := ScanWithGapLimitWithDeriver(mnemonic, 1, checker, d, externalChain(84, 0))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(used) != 1 || used[0] != "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu" {
+		t.Errorf("Expected the first BIP84 address to be used, got %v", used)
+	}
+	if checker.checked != 2 {
+		t.Errorf("Expected 2 addresses checked, got %d", checker.checked)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// ErrBatch is returned by DeriveFirstAddresses when some mnemonics could not
+// be derived. Failed maps the index of each failing mnemonic in the input to
+// its error; the other addresses are still returned.
+type ErrBatch struct {
+	Failed map[int]error
+}
+
+func (e ErrBatch) Error() string {
+	if len(e.Failed) == 0 {
+		return "batch failed"
+	}
+
+	indices := make([]int, 0, len(e.Failed))
+	for i := range e.Failed {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+
+	first := indices[0]
+	return fmt.Sprintf("%d mnemonics failed, first at index %d: %v", len(indices), first, e.Failed[first])
+}
+
+// DeriveFirstAddresses derives the first BIP44 address (m/44'/coin'/0'/0/0)
+// of every mnemonic on the given network using workers goroutines, so the
+// PBKDF2 seed computations run in parallel. addresses[i] belongs to
+// mnemonics[i]. A failing mnemonic leaves an empty string at its index and
+// is reported in an ErrBatch instead of aborting the batch.
+func DeriveFirstAddresses(mnemonics []string, workers int, params *chaincfg.Params) ([]string, error) {
+	d := btcDeriver{kind: P2PKH, params: params}
+	return DeriveFirstAddressesWithDeriver(mnemonics, workers, d, childPath(externalChain(44, params.HDCoinType), 0))
+}
+
+// DeriveFirstAddressesWithDeriver is DeriveFirstAddresses for any coin and
+// address type: it derives the address at path of every mnemonic with d,
+// e.g. a Deriver from GetDeriver.
+func DeriveFirstAddressesWithDeriver(mnemonics []string, workers int, d Deriver, path []uint32) ([]string, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	addresses := make([]string, len(mnemonics))
+	failed := make(map[int]error)
+	var mu sync.Mutex
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				address, err := deriveAddress(d, mnemonics[i], path)
+				if err != nil {
+					mu.Lock()
+					failed[i] = err
+					mu.Unlock()
+					continue
+				}
+				// Each index is written by exactly one worker
+				addresses[i] = address
+			}
+		}()
+	}
+
+	for i := range mnemonics {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if len(failed) > 0 {
+		return addresses, ErrBatch{Failed: failed}
+	}
+	return addresses, nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestDeriveFirstAddresses(t *testing.T) {
+	mnemonics := []string{
+		"mother author steel speak help absurd feature flee photo distance broken long",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"not a mnemonic",
+		"mother author steel speak help absurd feature flee photo distance broken long",
+	}
+	expected := []string{
+		"19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD",
+		"1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA",
+		"",
+		"19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD",
+	}
+
+	for _, workers := range []int{0, 1, 3, 8} {
+		addresses, err := DeriveFirstAddresses(mnemonics, workers, &chaincfg.MainNetParams)
+
+		var batchErr ErrBatch
+		if !errors.As(err, &batchErr) {
+			t.Fatalf("workers=%d: expected ErrBatch, got %v", workers, err)
+		}
+		if len(batchErr.Failed) != 1 || !errors.Is(batchErr.Failed[2], ErrInvalidMnemonic) {
+			t.Errorf("workers=%d: expected only index 2 to fail, got %v", workers, batchErr.Failed)
+		}
+
+		if len(addresses) != len(expected) {
+			t.Fatalf("workers=%d: expected %d addresses, got %d", workers, len(expected), len(addresses))
+		}
+		for i := range expected {
+			if addresses[i] != expected[i] {
+				t.Errorf("workers=%d: address %d: expected %q, got %q", workers, i, expected[i], addresses[i])
+			}
+		}
+	}
+}
+
+func TestDeriveFirstAddresses_AllValid(t *testing.T) {
+	addresses, err := DeriveFirstAddresses([]string{
+		"mother author steel speak help absurd feature flee photo distance broken long",
+	}, 2, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(addresses) != 1 || addresses[0] != "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD" {
+		t.Errorf("Expected [19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD], got %v", addresses)
+	}
+}
+
+func TestDeriveFirstAddressesWithDeriver(t *testing.T) {
+	mnemonics := []string{
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"not a mnemonic",
+	}
+
+	tests := []struct {
+		coin     string
+		path     []uint32
+		expected string
+	}{
+		{"btc-segwit", childPath(externalChain(84, 0), 0), "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"},
+		{"eth", childPath(externalChain(44, 60), 0), "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"},
+	}
+
+	for _, tt := range tests {
+		d, _ := GetDeriver(tt.coin)
+		addresses, err := DeriveFirstAddressesWithDeriver(mnemonics, 2, d, tt.path)
+
+		var batchErr ErrBatch
+		if !errors.As(err, &batchErr) || len(batchErr.Failed) != 1 {
+			t.Fatalf("%s: expected index 1 to fail, got %v", tt.coin, err)
+		}
+		if addresses[0] != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.coin, tt.expected, addresses[0])
+		}
+	}
+}
+
+func TestErrBatch_Error(t *testing.T) {
+	if msg := (ErrBatch{}).Error(); msg != "batch failed" {
+		t.Errorf("Expected a generic message for the zero value, got %q", msg)
+	}
+
+	err := ErrBatch{Failed: map[int]error{4: ErrInvalidMnemonic, 2: ErrInvalidMnemonic}}
+	if msg := err.Error(); msg != "2 mnemonics failed, first at index 2: "+ErrInvalidMnemonic.Error() {
+		t.Errorf("Unexpected message %q", msg)
+	}
+}
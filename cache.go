@@ -0,0 +1,132 @@
+package main
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+)
+
+// AddressCache is a thread-safe LRU cache of derived addresses keyed by
+// mnemonic and derivation path. Keys are SHA-256 digests, so the cache does
+// not keep the phrases themselves in memory. A cache only knows paths, not
+// coins or address types, so give each CachedDeriver its own cache.
+type AddressCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // most recently used at the front
+	entries map[[sha256.Size]byte]*list.Element
+}
+
+// addressCacheEntry is the value stored in AddressCache.order
+type addressCacheEntry struct {
+	key     [sha256.Size]byte
+	address string
+}
+
+// NewAddressCache returns an AddressCache holding at most size addresses.
+// A size below 1 is treated as 1.
+func NewAddressCache(size int) *AddressCache {
+	if size < 1 {
+		size = 1
+	}
+	return &AddressCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[[sha256.Size]byte]*list.Element),
+	}
+}
+
+// addressCacheKey digests mnemonic and path into a cache key
+func addressCacheKey(mnemonic string, path []uint32) [sha256.Size]byte {
+	h := sha256.New()
+	h.Write([]byte(collapseWhitespace(mnemonic)))
+	h.Write([]byte{0}) // no word contains a zero byte, so this ends the phrase unambiguously
+	for _, child := range path {
+		binary.Write(h, binary.BigEndian, child)
+	}
+
+	var key [sha256.Size]byte
+	h.Sum(key[:0])
+	return key
+}
+
+// Get returns the cached address for mnemonic at path and marks it as
+// recently used
+func (c *AddressCache) Get(mnemonic string, path []uint32) (string, bool) {
+	key := addressCacheKey(mnemonic, path)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*addressCacheEntry).address, true
+}
+
+// Add caches address for mnemonic at path, evicting the least recently used
+// address when the cache is full
+func (c *AddressCache) Add(mnemonic string, path []uint32, address string) {
+	key := addressCacheKey(mnemonic, path)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*addressCacheEntry).address = address
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&addressCacheEntry{key: key, address: address})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*addressCacheEntry).key)
+	}
+}
+
+// Len returns the number of cached addresses
+func (c *AddressCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// CachedDeriver derives addresses from mnemonics with a Deriver, remembering
+// the results in an AddressCache so repeated lookups skip the PBKDF2 seed
+// computation and the derivation entirely
+type CachedDeriver struct {
+	deriver Deriver
+	cache   *AddressCache
+}
+
+// NewCachedDeriver returns a CachedDeriver deriving with d and caching in
+// cache, e.g. NewCachedDeriver(d, NewAddressCache(1000))
+func NewCachedDeriver(d Deriver, cache *AddressCache) *CachedDeriver {
+	return &CachedDeriver{deriver: d, cache: cache}
+}
+
+// Address returns the address of mnemonic at path, deriving and caching it
+// on a miss. Failed derivations are not cached.
+func (d *CachedDeriver) Address(mnemonic string, path []uint32) (string, error) {
+	if address, ok := d.cache.Get(mnemonic, path); ok {
+		return address, nil
+	}
+
+	if !IsMnemonicValid(mnemonic) {
+		return "", ErrInvalidMnemonic
+	}
+	seed := newSeed(mnemonic, "")
+	defer Zero(seed)
+
+	address, err := d.deriver.Address(seed, path)
+	if err != nil {
+		return "", err
+	}
+	d.cache.Add(mnemonic, path, address)
+	return address, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+)
+
+func TestAddressCache_Eviction(t *testing.T) {
+	cache := NewAddressCache(2)
+	path := []uint32{0}
+
+	cache.Add("a", path, "1")
+	cache.Add("b", path, "2")
+	if _, ok := cache.Get("a", path); !ok { // a is now the most recently used
+		t.Fatalf("Expected a to be cached")
+	}
+	cache.Add("c", path, "3")
+
+	if _, ok := cache.Get("b", path); ok {
+		t.Errorf("Expected b to be evicted as least recently used")
+	}
+	if address, ok := cache.Get("a", path); !ok || address != "1" {
+		t.Errorf("Expected a to map to 1, got %q (%v)", address, ok)
+	}
+	if address, ok := cache.Get("c", path); !ok || address != "3" {
+		t.Errorf("Expected c to map to 3, got %q (%v)", address, ok)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 cached addresses, got %d", cache.Len())
+	}
+
+	// Paths are part of the key
+	if _, ok := cache.Get("a", []uint32{1}); ok {
+		t.Errorf("Expected a miss for another path")
+	}
+}
+
+// countingDeriver counts the derivations that reach the underlying Deriver
+type countingDeriver struct {
+	Deriver
+	mu    sync.Mutex
+	calls int
+}
+
+func (d *countingDeriver) Address(seed []byte, path []uint32) (string, error) {
+	d.mu.Lock()
+	d.calls++
+	d.mu.Unlock()
+	return d.Deriver.Address(seed, path)
+}
+
+func TestCachedDeriver(t *testing.T) {
+	btc, _ := GetDeriver("btc")
+	counter := &countingDeriver{Deriver: btc}
+	d := NewCachedDeriver(counter, NewAddressCache(10))
+
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	path := []uint32{
+		hdkeychain.HardenedKeyStart + 44,
+		hdkeychain.HardenedKeyStart,
+		hdkeychain.HardenedKeyStart,
+		0,
+		0,
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := d.Address(mnemonic, path); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	calls := counter.calls
+	address, err := d.Address(mnemonic, path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if address != "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA" {
+		t.Errorf("Expected address 1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA, got %s", address)
+	}
+	if counter.calls != calls {
+		t.Errorf("Expected a cache hit, got %d derivations", counter.calls-calls)
+	}
+
+	if _, err := d.Address("invalid mnemonic phrase", path); err == nil {
+		t.Errorf("Expected an error for invalid mnemonic")
+	}
+}
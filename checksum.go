@@ -0,0 +1,151 @@
+package main
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+)
+
+// validLastWordIndices returns, in ascending order, every word index that
+// completes the given prefix of word indices into a mnemonic with a valid
+// BIP39 checksum. The prefix must be one word short of a valid phrase length.
+func validLastWordIndices(prefix []int) []int {
+	wordCount := len(prefix) + 1
+	checksumBits := uint(wordCount / 3)
+	entropyBits := uint(wordCount)*11 - checksumBits
+	freeBits := 11 - checksumBits // entropy bits carried by the final word
+
+	// Accumulate the 11 bits of each prefix word
+	prefixBits := new(big.Int)
+	for _, idx := range prefix {
+		prefixBits.Lsh(prefixBits, 11)
+		prefixBits.Or(prefixBits, big.NewInt(int64(idx)))
+	}
+	prefixBits.Lsh(prefixBits, freeBits)
+
+	indices := make([]int, 0, 1<<freeBits)
+	entropy := make([]byte, entropyBits/8)
+	value := new(big.Int)
+	for free := 0; free < 1<<freeBits; free++ {
+		value.Or(prefixBits, big.NewInt(int64(free)))
+		value.FillBytes(entropy)
+
+		hash := sha256.Sum256(entropy)
+		checksum := int(hash[0] >> (8 - checksumBits))
+		indices = append(indices, free<<checksumBits|checksum)
+	}
+
+	return indices
+}
+
+// ValidLastWords returns every word that completes an 11- or 23-word partial
+// phrase into a mnemonic with a valid checksum, in wordlist order. This is
+// useful for recovering a phrase whose last word was lost.
+func ValidLastWords(partial []string) ([]string, error) {
+	prefix, err := partialIndices(partial)
+	if err != nil {
+		return nil, err
+	}
+	return indicesToMnemonic(validLastWordIndices(prefix)), nil
+}
+
+// CountValidCompletions returns how many words complete an 11- or 23-word
+// partial phrase into a mnemonic with a valid checksum. The final word of an
+// n-word phrase carries n/3 checksum bits, leaving 2^(11-n/3) completions:
+// 128 for 12 words and 8 for 24. Any other count points at a checksum bug.
+func CountValidCompletions(partial []string) (int, error) {
+	prefix, err := partialIndices(partial)
+	if err != nil {
+		return 0, err
+	}
+
+	// Different free bits can map to the same word only if the checksum
+	// computation is broken, so count distinct indices
+	seen := make(map[int]bool)
+	for _, idx := range validLastWordIndices(prefix) {
+		seen[idx] = true
+	}
+	return len(seen), nil
+}
+
+// SuggestChecksumFix returns the valid mnemonics formed by replacing the
+// last word of a 12- or 24-word phrase with each word that satisfies the
+// checksum, in wordlist order. When every word is known but the checksum
+// fails, a wrong final word is the most likely mistake, and this narrows the
+// 2048 alternatives down to 128 (or 8 for 24 words). The phrase's own last
+// word is among the suggestions only if the phrase is already valid.
+func SuggestChecksumFix(phrase []string) ([]string, error) {
+	if len(phrase) != 12 && len(phrase) != 24 {
+		return nil, fmt.Errorf("phrase has %d words, expected 12 or 24", len(phrase))
+	}
+
+	prefix := phrase[:len(phrase)-1]
+	lastWords, err := ValidLastWords(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	words := append([]string(nil), phrase...)
+	suggestions := make([]string, len(lastWords))
+	for i, word := range lastWords {
+		words[len(words)-1] = word
+		suggestions[i] = JoinMnemonic(words, "")
+	}
+	return suggestions, nil
+}
+
+// partialIndices converts the words of an 11- or 23-word partial phrase to
+// their indices in the loaded wordlist
+func partialIndices(partial []string) ([]int, error) {
+	if len(partial) != 11 && len(partial) != 23 {
+		return nil, fmt.Errorf("partial phrase has %d words, expected 11 or 23", len(partial))
+	}
+	if len(BIP39Words) != BIP39WordlistSize {
+		return nil, fmt.Errorf("checksum computation requires a %d-word wordlist, got %d", BIP39WordlistSize, len(BIP39Words))
+	}
+
+	prefix := make([]int, len(partial))
+	for i, word := range partial {
+		idx, ok := WordIndex(word)
+		if !ok {
+			return nil, fmt.Errorf("word %d (%q) is not in the wordlist", i+1, word)
+		}
+		prefix[i] = idx
+	}
+	return prefix, nil
+}
+
+// hasValidChecksum reports whether the word indices of a full phrase carry a
+// valid BIP39 checksum in their final bits
+func hasValidChecksum(indices []int) bool {
+	wordCount := len(indices)
+	checksumBits := uint(wordCount / 3)
+	entropyBits := uint(wordCount)*11 - checksumBits
+
+	value := new(big.Int)
+	for _, idx := range indices {
+		value.Lsh(value, 11)
+		value.Or(value, big.NewInt(int64(idx)))
+	}
+
+	checksum := new(big.Int).And(value, big.NewInt(1<<checksumBits-1)).Int64()
+	entropy := make([]byte, entropyBits/8)
+	value.Rsh(value, checksumBits).FillBytes(entropy)
+
+	hash := sha256.Sum256(entropy)
+	return int64(hash[0]>>(8-checksumBits)) == checksum
+}
+
+// ChecksumBits returns the BIP39 checksum of entropy and its length in bits:
+// the top len(entropy)*8/32 bits of SHA-256(entropy). The checksum makes up
+// the low bits of the final mnemonic word, which helps track down checksum
+// mismatches against other implementations.
+func ChecksumBits(entropy []byte) (byte, int, error) {
+	if err := validateEntropyLength(entropy); err != nil {
+		return 0, 0, err
+	}
+
+	bits := len(entropy) * 8 / 32
+	hash := sha256.Sum256(entropy)
+	return hash[0] >> (8 - bits), bits, nil
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	bip39 "github.com/tyler-smith/go-bip39"
+)
+
+func TestValidLastWordIndices(t *testing.T) {
+	words := useEnglishWordlist(t)
+
+	tests := []struct {
+		prefixLen int
+		expected  int
+	}{
+		{11, 128}, // 4 checksum bits leave 7 free bits
+		{23, 8},   // 8 checksum bits leave 3 free bits
+	}
+
+	for _, tt := range tests {
+		prefix := make([]int, tt.prefixLen)
+		for i := range prefix {
+			prefix[i] = (i * 97) % len(words)
+		}
+
+		indices := validLastWordIndices(prefix)
+		if len(indices) != tt.expected {
+			t.Fatalf("Expected %d completions for %d words, got %d", tt.expected, tt.prefixLen, len(indices))
+		}
+
+		for _, idx := range indices {
+			mnemonic := strings.Join(indicesToMnemonic(append(append([]int(nil), prefix...), idx)), " ")
+			if !bip39.IsMnemonicValid(mnemonic) {
+				t.Errorf("Expected %q to be a valid mnemonic", mnemonic)
+			}
+		}
+	}
+}
+
+func TestValidLastWords(t *testing.T) {
+	useEnglishWordlist(t)
+
+	partial := strings.Fields("mother author steel speak help absurd feature flee photo distance broken")
+	lastWords, err := ValidLastWords(partial)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(lastWords) != 128 {
+		t.Fatalf("Expected 128 valid last words, got %d", len(lastWords))
+	}
+
+	// The original last word must be among the candidates
+	found := false
+	for _, word := range lastWords {
+		if word == "long" {
+			found = true
+		}
+		if !bip39.IsMnemonicValid(strings.Join(append(append([]string(nil), partial...), word), " ")) {
+			t.Errorf("Expected %q to complete a valid mnemonic", word)
+		}
+	}
+	if !found {
+		t.Errorf("Expected \"long\" among the valid last words")
+	}
+}
+
+func TestValidLastWords_Invalid(t *testing.T) {
+	useEnglishWordlist(t)
+
+	if _, err := ValidLastWords(strings.Fields("mother author steel")); err == nil {
+		t.Errorf("Expected an error for a 3-word partial phrase")
+	}
+	if _, err := ValidLastWords(strings.Fields("mother author steel speak help absurd feature flee photo distance brokenn")); err == nil {
+		t.Errorf("Expected an error for a word not in the wordlist")
+	}
+}
+
+func TestCountValidCompletions(t *testing.T) {
+	useEnglishWordlist(t)
+
+	tests := []struct {
+		partial  string
+		expected int
+	}{
+		{"mother author steel speak help absurd feature flee photo distance broken", 128},
+		{strings.Repeat("abandon ", 11), 128},
+		{strings.Repeat("zoo ", 23), 8},
+	}
+
+	for _, tt := range tests {
+		count, err := CountValidCompletions(strings.Fields(tt.partial))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if count != tt.expected {
+			t.Errorf("%q: expected %d completions, got %d", tt.partial, tt.expected, count)
+		}
+	}
+
+	if _, err := CountValidCompletions(strings.Fields("mother author steel")); err == nil {
+		t.Errorf("Expected an error for a 3-word partial phrase")
+	}
+}
+
+func TestSuggestChecksumFix(t *testing.T) {
+	useEnglishWordlist(t)
+
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+	broken := strings.Fields("mother author steel speak help absurd feature flee photo distance broken broken")
+
+	suggestions, err := SuggestChecksumFix(broken)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(suggestions) != 128 {
+		t.Fatalf("Expected 128 suggestions, got %d", len(suggestions))
+	}
+
+	found := false
+	for _, suggestion := range suggestions {
+		if suggestion == mnemonic {
+			found = true
+		}
+		if !bip39.IsMnemonicValid(suggestion) {
+			t.Errorf("Expected %q to be a valid mnemonic", suggestion)
+		}
+	}
+	if !found {
+		t.Errorf("Expected %q among the suggestions", mnemonic)
+	}
+
+	if _, err := SuggestChecksumFix(strings.Fields("mother author steel")); err == nil {
+		t.Errorf("Expected an error for a 3-word phrase")
+	}
+	if _, err := SuggestChecksumFix(strings.Fields("mother author steel speak help absurd featur flee photo distance broken long")); err == nil {
+		t.Errorf("Expected an error for a word not in the wordlist")
+	}
+}
+
+func TestChecksumBits(t *testing.T) {
+	useEnglishWordlist(t)
+
+	// "abandon ... about": SHA-256 of 16 zero bytes starts with 0x37
+	checksum, bits, err := ChecksumBits(make([]byte, 16))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if checksum != 3 || bits != 4 {
+		t.Errorf("Expected checksum 3 of 4 bits, got %d of %d bits", checksum, bits)
+	}
+
+	// The checksum is the low bits of the final word's index
+	for _, size := range []int{16, 20, 24, 28, 32} {
+		entropy := make([]byte, size)
+		for i := range entropy {
+			entropy[i] = byte(i * 37)
+		}
+
+		checksum, bits, err := ChecksumBits(entropy)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if bits != size*8/32 {
+			t.Errorf("%d bytes: expected %d checksum bits, got %d", size, size*8/32, bits)
+		}
+
+		mnemonic, err := EntropyToMnemonic(entropy)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		fields := strings.Fields(mnemonic)
+		last, _ := WordIndex(fields[len(fields)-1])
+		if byte(last&(1<<bits-1)) != checksum {
+			t.Errorf("%d bytes: expected checksum %d to match the final word %s", size, checksum, fields[len(fields)-1])
+		}
+	}
+
+	if _, _, err := ChecksumBits(make([]byte, 15)); err == nil {
+		t.Errorf("Expected an error for 120 bits of entropy")
+	}
+}
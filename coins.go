@@ -0,0 +1,43 @@
+package main
+
+import (
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// LitecoinMainNetParams holds the Litecoin mainnet parameters needed to
+// derive and encode its addresses. Only the fields used by this package are
+// set; the params are not registered with chaincfg.
+var LitecoinMainNetParams = chaincfg.Params{
+	Name:             "litecoin",
+	PubKeyHashAddrID: 0x30, // L...
+	ScriptHashAddrID: 0x32, // M...
+	PrivateKeyID:     0xb0,
+	Bech32HRPSegwit:  "ltc",
+	HDPrivateKeyID:   [4]byte{0x04, 0x88, 0xad, 0xe4}, // xprv
+	HDPublicKeyID:    [4]byte{0x04, 0x88, 0xb2, 0x1e}, // xpub
+	HDCoinType:       2,
+}
+
+// DogecoinMainNetParams holds the Dogecoin mainnet parameters needed to
+// derive and encode its addresses, like LitecoinMainNetParams.
+var DogecoinMainNetParams = chaincfg.Params{
+	Name:             "dogecoin",
+	PubKeyHashAddrID: 0x1e, // D...
+	ScriptHashAddrID: 0x16, // 9... or A...
+	PrivateKeyID:     0x9e,
+	HDPrivateKeyID:   [4]byte{0x02, 0xfa, 0xc3, 0x98}, // dgpv
+	HDPublicKeyID:    [4]byte{0x02, 0xfa, 0xca, 0xfd}, // dgub
+	HDCoinType:       3,
+}
+
+// GenerateLTCAddress generates the first BIP44 Litecoin address
+// (m/44'/2'/0'/0/0) of a BIP39 mnemonic.
+func GenerateLTCAddress(mnemonic string) (string, error) {
+	return deriveAddress(derivers["ltc"], mnemonic, childPath(externalChain(44, LitecoinMainNetParams.HDCoinType), 0))
+}
+
+// GenerateDOGEAddress generates the first BIP44 Dogecoin address
+// (m/44'/3'/0'/0/0) of a BIP39 mnemonic.
+func GenerateDOGEAddress(mnemonic string) (string, error) {
+	return deriveAddress(derivers["doge"], mnemonic, childPath(externalChain(44, DogecoinMainNetParams.HDCoinType), 0))
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestGenerateLTCAddress(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	address, err := GenerateLTCAddress(mnemonic)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := "LUWPbpM43E2p7ZSh8cyTBEkvpHmr3cB8Ez"
+	if address != expected {
+		t.Errorf("Expected address %s, got %s", expected, address)
+	}
+}
+
+func TestGenerateDOGEAddress(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	address, err := GenerateDOGEAddress(mnemonic)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := "DBus3bamQjgJULBJtYXpEzDWQRwF5iwxgC"
+	if address != expected {
+		t.Errorf("Expected address %s, got %s", expected, address)
+	}
+}
+
+func TestGenerateAltcoinAddress_InvalidMnemonic(t *testing.T) {
+	if _, err := GenerateLTCAddress("invalid mnemonic phrase"); err == nil {
+		t.Errorf("Expected an error for invalid mnemonic")
+	}
+	if _, err := GenerateDOGEAddress("invalid mnemonic phrase"); err == nil {
+		t.Errorf("Expected an error for invalid mnemonic")
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// SharedAddresses returns the BIP44 receiving addresses that appear among the
+// first count addresses of both m1 and m2 on the given network, in m1's
+// derivation order. Two distinct backups should never share an address, so a
+// non-empty result points at an accidentally duplicated phrase.
+func SharedAddresses(m1, m2 string, count int, params *chaincfg.Params) ([]string, error) {
+	d := btcDeriver{kind: P2PKH, params: params}
+	return SharedAddressesWithDeriver(m1, m2, count, d, externalChain(44, params.HDCoinType))
+}
+
+// SharedAddressesWithDeriver is SharedAddresses for any coin and address
+// type: it compares the first count addresses chain/i of both mnemonics,
+// derived with d.
+func SharedAddressesWithDeriver(m1, m2 string, count int, d Deriver, chain []uint32) ([]string, error) {
+	first, err := chainAddresses(d, m1, chain, count)
+	if err != nil {
+		return nil, fmt.Errorf("first mnemonic: %v", err)
+	}
+	second, err := chainAddresses(d, m2, chain, count)
+	if err != nil {
+		return nil, fmt.Errorf("second mnemonic: %v", err)
+	}
+
+	seen := make(map[string]bool, len(second))
+	for _, address := range second {
+		seen[address] = true
+	}
+
+	var shared []string
+	for _, address := range first {
+		if seen[address] {
+			shared = append(shared, address)
+		}
+	}
+	return shared, nil
+}
+
+// DiffMnemonics returns the zero-based positions at which the words of a and
+// b differ, for proofreading a copied backup against the original. Phrases
+// are compared after NormalizeMnemonic, so case and spacing do not count as
+// differences. Neither phrase has to be valid, but both must have the same
+// number of words.
+func DiffMnemonics(a, b string) ([]int, error) {
+	wordsA := strings.Fields(NormalizeMnemonic(a))
+	wordsB := strings.Fields(NormalizeMnemonic(b))
+	if len(wordsA) != len(wordsB) {
+		return nil, fmt.Errorf("mnemonics have different word counts: %d and %d", len(wordsA), len(wordsB))
+	}
+
+	var diff []int
+	for i := range wordsA {
+		if wordsA[i] != wordsB[i] {
+			diff = append(diff, i)
+		}
+	}
+	return diff, nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestSharedAddresses(t *testing.T) {
+	m1 := "mother author steel speak help absurd feature flee photo distance broken long"
+	m2 := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	shared, err := SharedAddresses(m1, m1, 3, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(shared) != 3 || shared[0] != "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD" {
+		t.Errorf("Expected all 3 addresses shared, got %v", shared)
+	}
+
+	shared, err = SharedAddresses(m1, m2, 3, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(shared) != 0 {
+		t.Errorf("Expected no shared addresses, got %v", shared)
+	}
+}
+
+func TestSharedAddresses_Errors(t *testing.T) {
+	valid := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	tests := []struct {
+		name  string
+		m1    string
+		m2    string
+		count int
+	}{
+		{"invalid first", "not a mnemonic", valid, 1},
+		{"invalid second", valid, "not a mnemonic", 1},
+		{"zero count", valid, valid, 0},
+	}
+
+	for _, tt := range tests {
+		if _, err := SharedAddresses(tt.m1, tt.m2, tt.count, &chaincfg.MainNetParams); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestDiffMnemonics(t *testing.T) {
+	original := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	tests := []struct {
+		name     string
+		copy     string
+		expected []int
+	}{
+		{"identical", original, nil},
+		{"case and spacing", "Mother  author steel speak help absurd feature flee photo distance broken\nlong", nil},
+		{"one word", "mother author steel speak help absurd feature free photo distance broken long", []int{7}},
+		{"swapped", "author mother steel speak help absurd feature flee photo distance broken long", []int{0, 1}},
+	}
+
+	for _, tt := range tests {
+		diff, err := DiffMnemonics(original, tt.copy)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.name, err)
+		}
+		if len(diff) != len(tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, diff)
+			continue
+		}
+		for i := range diff {
+			if diff[i] != tt.expected[i] {
+				t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, diff)
+				break
+			}
+		}
+	}
+
+	if _, err := DiffMnemonics(original, "mother author steel"); err == nil {
+		t.Errorf("Expected an error for mismatched word counts")
+	}
+}
+
+func TestSharedAddressesWithDeriver(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	d, _ := GetDeriver("eth")
+
+	shared, err := SharedAddressesWithDeriver(mnemonic, mnemonic, 2, d, externalChain(44, 60))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(shared) != 2 || shared[0] != "0x9858EfFD232B4033E47d90003D41EC34EcaEda94" {
+		t.Errorf("Expected both Ethereum addresses shared, got %v", shared)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// Deriver turns a BIP39 seed and a derivation path into an address of one
+// coin and address type, so batch tools can work with any registered coin.
+// Hardened levels of path must already include hdkeychain.HardenedKeyStart.
+type Deriver interface {
+	Address(seed []byte, path []uint32) (string, error)
+}
+
+// btcDeriver derives Bitcoin-style addresses of one kind for a network
+type btcDeriver struct {
+	kind   AddressKind
+	params *chaincfg.Params
+}
+
+func (d btcDeriver) Address(seed []byte, path []uint32) (string, error) {
+	key, err := deriveKeyFromSeed(seed, path, d.params)
+	if err != nil {
+		return "", err
+	}
+	defer key.Zero()
+	return encodeAddress(key, d.kind, d.params)
+}
+
+// ethDeriver derives EIP-55 checksummed Ethereum addresses
+type ethDeriver struct{}
+
+func (ethDeriver) Address(seed []byte, path []uint32) (string, error) {
+	key, err := deriveKeyFromSeed(seed, path, &chaincfg.MainNetParams)
+	if err != nil {
+		return "", err
+	}
+	defer key.Zero()
+	return ethAddress(key)
+}
+
+// derivers maps the coin names accepted by GetDeriver to their Deriver
+var derivers = map[string]Deriver{
+	"btc":               btcDeriver{kind: P2PKH, params: &chaincfg.MainNetParams},
+	"btc-nested-segwit": btcDeriver{kind: P2SH, params: &chaincfg.MainNetParams},
+	"btc-segwit":        btcDeriver{kind: P2WPKH, params: &chaincfg.MainNetParams},
+	"btc-taproot":       btcDeriver{kind: P2TR, params: &chaincfg.MainNetParams},
+	"eth":               ethDeriver{},
+	"ltc":               btcDeriver{kind: P2PKH, params: &LitecoinMainNetParams},
+	"doge":              btcDeriver{kind: P2PKH, params: &DogecoinMainNetParams},
+}
+
+// GetDeriver returns the Deriver registered for coin: "btc" (legacy P2PKH),
+// "btc-nested-segwit", "btc-segwit", "btc-taproot", "eth", "ltc" or "doge"
+func GetDeriver(coin string) (Deriver, bool) {
+	d, ok := derivers[coin]
+	return d, ok
+}
+
+// externalChain returns the path m/purpose'/coinType'/0'/0 of the receiving
+// addresses of a coin's first account
+func externalChain(purpose, coinType uint32) []uint32 {
+	return []uint32{Hardened(purpose), Hardened(coinType), Hardened(0), 0}
+}
+
+// childPath returns path extended by index without modifying path
+func childPath(path []uint32, index uint32) []uint32 {
+	return append(append(make([]uint32, 0, len(path)+1), path...), index)
+}
+
+// deriveAddress validates a mnemonic and derives its address at path with d
+func deriveAddress(d Deriver, mnemonic string, path []uint32) (string, error) {
+	if !IsMnemonicValid(mnemonic) {
+		return "", ErrInvalidMnemonic
+	}
+	seed := newSeed(mnemonic, "")
+	defer Zero(seed)
+	return d.Address(seed, path)
+}
+
+// chainAddresses derives the first count addresses chain/0 .. chain/count-1
+// of a mnemonic with d. The seed is computed once for all of them.
+func chainAddresses(d Deriver, mnemonic string, chain []uint32, count int) ([]string, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("invalid address count: %d", count)
+	}
+	if !IsMnemonicValid(mnemonic) {
+		return nil, ErrInvalidMnemonic
+	}
+	seed := newSeed(mnemonic, "")
+	defer Zero(seed)
+
+	addresses := make([]string, count)
+	for i := range addresses {
+		address, err := d.Address(seed, childPath(chain, uint32(i)))
+		if err != nil {
+			return addresses[:i], err
+		}
+		addresses[i] = address
+	}
+	return addresses, nil
+}
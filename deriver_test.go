@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+)
+
+func TestGetDeriver(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	seed := newSeed(mnemonic, "")
+
+	tests := []struct {
+		coin     string
+		purpose  uint32
+		coinType uint32
+		expected string
+	}{
+		{"btc", 44, 0, "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA"},
+		{"btc-nested-segwit", 49, 0, "37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf"},
+		{"btc-segwit", 84, 0, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"},
+		{"btc-taproot", 86, 0, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"},
+		{"eth", 44, 60, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"},
+		{"ltc", 44, 2, "LUWPbpM43E2p7ZSh8cyTBEkvpHmr3cB8Ez"},
+		{"doge", 44, 3, "DBus3bamQjgJULBJtYXpEzDWQRwF5iwxgC"},
+	}
+
+	for _, tt := range tests {
+		d, ok := GetDeriver(tt.coin)
+		if !ok {
+			t.Fatalf("Expected a deriver for %s", tt.coin)
+		}
+
+		address, err := d.Address(seed, []uint32{
+			hdkeychain.HardenedKeyStart + tt.purpose,
+			hdkeychain.HardenedKeyStart + tt.coinType,
+			hdkeychain.HardenedKeyStart,
+			0,
+			0,
+		})
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.coin, err)
+		}
+		if address != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.coin, tt.expected, address)
+		}
+	}
+
+	if _, ok := GetDeriver("xmr"); ok {
+		t.Errorf("Expected no deriver for an unregistered coin")
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"unicode"
+)
+
+// minDiceRolls is how many rolls of a fair six-sided die carry at least 256
+// bits of entropy: ceil(256 / log2(6))
+const minDiceRolls = 100
+
+// MnemonicFromDiceRolls builds a 24-word mnemonic from 256 bits of entropy
+// supplied as die rolls, for users who do not trust the system RNG.
+//
+// The rolls are read as the digits of a base-6 number, most significant
+// first, with faces 1-6 standing for the digits 0-5; whitespace between rolls
+// is ignored. The low 256 bits of that number are the entropy. At least 100
+// rolls are required; 6^100 values reduced to 256 bits lose less than 0.1 bit
+// of entropy to the reduction. Only rolls of a fair die give a secure wallet.
+func MnemonicFromDiceRolls(rolls string) (string, error) {
+	value := new(big.Int)
+	six := big.NewInt(6)
+	count := 0
+	for i, r := range rolls {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		if r < '1' || r > '6' {
+			return "", fmt.Errorf("invalid roll %q at position %d, must be 1-6", r, i)
+		}
+		value.Mul(value, six)
+		value.Add(value, big.NewInt(int64(r-'1')))
+		count++
+	}
+
+	if count < minDiceRolls {
+		return "", fmt.Errorf("got %d dice rolls, need at least %d for 256 bits of entropy", count, minDiceRolls)
+	}
+
+	// Keep the low 256 bits
+	entropy := make([]byte, 32)
+	value.And(value, new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1)))
+	value.FillBytes(entropy)
+
+	return EntropyToMnemonic(entropy)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMnemonicFromDiceRolls(t *testing.T) {
+	// All ones are the base-6 number zero: all-zero entropy
+	mnemonic, err := MnemonicFromDiceRolls(strings.Repeat("1", 100))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := strings.Repeat("abandon ", 23) + "art"
+	if mnemonic != expected {
+		t.Errorf("Expected %q, got %q", expected, mnemonic)
+	}
+
+	// 1...12 is the number one: the entropy's last bit is set
+	mnemonic, err = MnemonicFromDiceRolls(strings.Repeat("1", 99) + "2")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	entropy, err := MnemonicToEntropy(mnemonic)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if entropy[31] != 1 {
+		t.Errorf("Expected entropy ending in 01, got %x", entropy)
+	}
+
+	// Whitespace between rolls is ignored
+	rolls := strings.Repeat("3164 2552 ", 13)
+	spaced, err := MnemonicFromDiceRolls(rolls)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	compact, _ := MnemonicFromDiceRolls(strings.ReplaceAll(rolls, " ", ""))
+	if spaced != compact {
+		t.Errorf("Expected whitespace not to change the mnemonic")
+	}
+	if WordCount(spaced) != 24 {
+		t.Errorf("Expected 24 words, got %d", WordCount(spaced))
+	}
+}
+
+func TestMnemonicFromDiceRolls_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"too few rolls": strings.Repeat("6", 99),
+		"zero":          strings.Repeat("1", 99) + "0",
+		"seven":         strings.Repeat("1", 99) + "7",
+		"letters":       strings.Repeat("1", 99) + "a",
+	}
+
+	for name, rolls := range tests {
+		if _, err := MnemonicFromDiceRolls(rolls); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
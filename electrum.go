@@ -0,0 +1,83 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// electrumSeedPrefixes maps the hex prefixes of Electrum's seed version HMAC
+// to the seed types they mark, longest first so no prefix shadows another
+var electrumSeedPrefixes = []struct {
+	prefix   string
+	seedType string
+}{
+	{"100", "segwit"},
+	{"101", "2fa"},
+	{"102", "2fa_segwit"},
+	{"01", "standard"},
+}
+
+// IsElectrumSeed reports whether phrase is an Electrum "new style" seed and
+// returns its type: "standard", "segwit", "2fa" or "2fa_segwit". Electrum
+// seeds are not BIP39 phrases: they carry no checksum, and their version is
+// the prefix of HMAC-SHA512(key "Seed version", normalized phrase). A phrase
+// that fails BIP39 validation may still be a perfectly good Electrum seed.
+func IsElectrumSeed(phrase string) (bool, string) {
+	mac := hmac.New(sha512.New, []byte("Seed version"))
+	mac.Write([]byte(normalizeElectrumSeed(phrase)))
+	digest := hex.EncodeToString(mac.Sum(nil))
+
+	for _, p := range electrumSeedPrefixes {
+		if strings.HasPrefix(digest, p.prefix) {
+			return true, p.seedType
+		}
+	}
+	return false, ""
+}
+
+// normalizeElectrumSeed applies Electrum's seed normalization: NFKD, lower
+// case, accents stripped, whitespace collapsed and whitespace between CJK
+// characters removed
+func normalizeElectrumSeed(phrase string) string {
+	var b strings.Builder
+	for _, r := range norm.NFKD.String(strings.ToLower(phrase)) {
+		if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(r)
+		}
+	}
+	words := strings.Fields(b.String())
+
+	b.Reset()
+	for i, word := range words {
+		if i > 0 && !(isCJK(lastRune(words[i-1])) && isCJK(firstRune(word))) {
+			b.WriteByte(' ')
+		}
+		b.WriteString(word)
+	}
+	return b.String()
+}
+
+// isCJK reports whether r belongs to a script Electrum writes without spaces
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+func firstRune(s string) rune {
+	for _, r := range s {
+		return r
+	}
+	return 0
+}
+
+func lastRune(s string) rune {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return 0
+	}
+	return runes[len(runes)-1]
+}
@@ -0,0 +1,46 @@
+package main
+
+import "testing"
+
+func TestIsElectrumSeed(t *testing.T) {
+	tests := []struct {
+		phrase   string
+		ok       bool
+		seedType string
+	}{
+		{"cycle rocket west magnet parrot shuffle foot correct salt library feed song", true, "standard"},
+		{"wild father tree among universe such mobile favorite target dynamic credit identify", true, "segwit"},
+		{"bitter grass shiver impose acquire brush forget axis eager alone wine silver", true, "segwit"},
+		// Electrum normalizes case and whitespace before hashing
+		{"  Wild father TREE among\tuniverse such mobile favorite target dynamic credit identify", true, "segwit"},
+		// A BIP39 phrase is not an Electrum seed
+		{"mother author steel speak help absurd feature flee photo distance broken long", false, ""},
+		{"", false, ""},
+	}
+
+	for _, tt := range tests {
+		ok, seedType := IsElectrumSeed(tt.phrase)
+		if ok != tt.ok || seedType != tt.seedType {
+			t.Errorf("%q: expected (%v, %q), got (%v, %q)", tt.phrase, tt.ok, tt.seedType, ok, seedType)
+		}
+	}
+}
+
+func TestNormalizeElectrumSeed(t *testing.T) {
+	tests := []struct {
+		phrase   string
+		expected string
+	}{
+		{" Abandon  ABILITY\n", "abandon ability"},
+		{"café crème", "cafe creme"},
+		// Like accents, the decomposed kana voicing marks are stripped
+		{"あいこく しんぱい　あおぞら", "あいこくしんはいあおそら"},
+		{"あいこく abc しんぱい", "あいこく abc しんはい"},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeElectrumSeed(tt.phrase); got != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.phrase, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"strings"
+
+	bip39 "github.com/tyler-smith/go-bip39"
+)
+
+// validateEntropyLength checks that entropy is one of the BIP39 strengths
+// (128, 160, 192, 224 or 256 bits)
+func validateEntropyLength(entropy []byte) error {
+	bits := len(entropy) * 8
+	if bits < 128 || bits > 256 || bits%32 != 0 {
+		return fmt.Errorf("invalid entropy length: %d bits, must be 128, 160, 192, 224 or 256", bits)
+	}
+	return nil
+}
+
+// NewRandomMnemonic generates a new mnemonic from bits of cryptographically
+// secure entropy. bits must be 128, 160, 192, 224 or 256.
+func NewRandomMnemonic(bits int) (string, error) {
+	return NewRandomMnemonicFromReader(rand.Reader, bits)
+}
+
+// NewRandomMnemonicFromReader is NewRandomMnemonic reading its entropy from
+// r instead of crypto/rand, so tests can supply a fixed byte source and
+// expect an exact phrase. Only pass a cryptographically secure source when
+// generating real wallets.
+func NewRandomMnemonicFromReader(r io.Reader, bits int) (string, error) {
+	if bits < 128 || bits > 256 || bits%32 != 0 {
+		return "", fmt.Errorf("invalid mnemonic strength: %d bits, must be 128, 160, 192, 224 or 256", bits)
+	}
+
+	entropy := make([]byte, bits/8)
+	if _, err := io.ReadFull(r, entropy); err != nil {
+		return "", fmt.Errorf("failed to read entropy: %v", err)
+	}
+
+	return EntropyToMnemonic(entropy)
+}
+
+// NewRandomMnemonicWithWords generates a new mnemonic of the given number of
+// words (12, 15, 18, 21 or 24) from cryptographically secure entropy
+func NewRandomMnemonicWithWords(words int) (string, error) {
+	bits, err := wordCountToBits(words)
+	if err != nil {
+		return "", err
+	}
+	return NewRandomMnemonic(bits)
+}
+
+// EntropyToMnemonic converts raw entropy bytes into a BIP39 mnemonic
+func EntropyToMnemonic(entropy []byte) (string, error) {
+	if err := validateEntropyLength(entropy); err != nil {
+		return "", err
+	}
+
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", fmt.Errorf("failed to create mnemonic: %v", err)
+	}
+
+	return FormatMnemonic(strings.Fields(mnemonic)), nil
+}
+
+// MnemonicToEntropy recovers the raw entropy bytes of a BIP39 mnemonic,
+// validating its word count, words and checksum
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	entropy, err := bip39.EntropyFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %v", err)
+	}
+
+	return entropy, nil
+}
+
+// isValidWordCount reports whether n is a BIP39 phrase length (12, 15, 18, 21 or 24)
+func isValidWordCount(n int) bool {
+	return n >= 12 && n <= 24 && n%3 == 0
+}
+
+// WordCount returns the number of words in a mnemonic, however they are separated
+func WordCount(mnemonic string) int {
+	return len(strings.Fields(mnemonic))
+}
+
+// wordCountToBits returns the entropy strength of a phrase length, or an
+// error if words is not a BIP39 phrase length
+func wordCountToBits(words int) (int, error) {
+	if !isValidWordCount(words) {
+		return 0, fmt.Errorf("invalid word count: %d, must be 12, 15, 18, 21 or 24", words)
+	}
+	return words / 3 * 32, nil
+}
+
+// MnemonicStrength returns the entropy strength in bits of a mnemonic, 128,
+// 160, 192, 224 or 256, from its word count. Only the length is checked, not
+// the words or the checksum.
+func MnemonicStrength(mnemonic string) (int, error) {
+	return wordCountToBits(WordCount(mnemonic))
+}
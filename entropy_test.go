@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	bip39 "github.com/tyler-smith/go-bip39"
+)
+
+func TestEntropyToMnemonic(t *testing.T) {
+	// BIP39 test vectors
+	tests := []struct {
+		entropy  string
+		mnemonic string
+	}{
+		{"00000000000000000000000000000000", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"},
+		{"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f", "legal winner thank year wave sausage worth useful legal winner thank yellow"},
+		{"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote"},
+	}
+
+	for _, tt := range tests {
+		entropy, _ := hex.DecodeString(tt.entropy)
+
+		mnemonic, err := EntropyToMnemonic(entropy)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if mnemonic != tt.mnemonic {
+			t.Errorf("Expected mnemonic %q, got %q", tt.mnemonic, mnemonic)
+		}
+
+		roundTrip, err := MnemonicToEntropy(mnemonic)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !bytes.Equal(roundTrip, entropy) {
+			t.Errorf("Expected entropy %x, got %x", entropy, roundTrip)
+		}
+	}
+}
+
+func TestEntropyToMnemonic_InvalidLength(t *testing.T) {
+	for _, size := range []int{0, 8, 15, 17, 33} {
+		if _, err := EntropyToMnemonic(make([]byte, size)); err == nil {
+			t.Errorf("Expected an error for %d bytes of entropy", size)
+		}
+	}
+}
+
+func TestMnemonicToEntropy_InvalidChecksum(t *testing.T) {
+	// All words are valid but the checksum is not
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon"
+
+	if _, err := MnemonicToEntropy(mnemonic); err == nil {
+		t.Fatalf("Expected an error for an invalid checksum")
+	}
+}
+
+func TestNewRandomMnemonic(t *testing.T) {
+	for _, bits := range []int{128, 160, 192, 224, 256} {
+		mnemonic, err := NewRandomMnemonic(bits)
+		if err != nil {
+			t.Fatalf("%d bits: expected no error, got %v", bits, err)
+		}
+
+		expectedWords := bits / 32 * 3
+		if words := len(strings.Fields(mnemonic)); words != expectedWords {
+			t.Errorf("%d bits: expected %d words, got %d", bits, expectedWords, words)
+		}
+		if !bip39.IsMnemonicValid(mnemonic) {
+			t.Errorf("%d bits: expected a valid mnemonic, got %q", bits, mnemonic)
+		}
+	}
+
+	first, _ := NewRandomMnemonic(128)
+	second, _ := NewRandomMnemonic(128)
+	if first == second {
+		t.Errorf("Expected two random mnemonics to differ, both were %q", first)
+	}
+}
+
+func TestNewRandomMnemonicFromReader(t *testing.T) {
+	tests := []struct {
+		entropy  []byte
+		bits     int
+		expected string
+	}{
+		{make([]byte, 16), 128, "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"},
+		{bytes.Repeat([]byte{0x7f}, 16), 128, "legal winner thank year wave sausage worth useful legal winner thank yellow"},
+		{bytes.Repeat([]byte{0xff}, 32), 256, "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote"},
+	}
+
+	for _, tt := range tests {
+		mnemonic, err := NewRandomMnemonicFromReader(bytes.NewReader(tt.entropy), tt.bits)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if mnemonic != tt.expected {
+			t.Errorf("Expected %q, got %q", tt.expected, mnemonic)
+		}
+	}
+
+	// A source that runs dry is an error, not a weaker mnemonic
+	if _, err := NewRandomMnemonicFromReader(bytes.NewReader(make([]byte, 8)), 128); err == nil {
+		t.Errorf("Expected an error for a short entropy source")
+	}
+}
+
+func TestNewRandomMnemonic_InvalidBits(t *testing.T) {
+	for _, bits := range []int{0, 96, 127, 130, 288} {
+		if _, err := NewRandomMnemonic(bits); err == nil {
+			t.Errorf("Expected an error for %d bits", bits)
+		}
+	}
+}
+
+func TestWordCount(t *testing.T) {
+	tests := map[string]int{
+		"":                           0,
+		"abandon":                    1,
+		"  abandon \t ability\nable": 3,
+		"mother author steel speak help absurd feature flee photo distance broken long": 12,
+	}
+
+	for mnemonic, expected := range tests {
+		if count := WordCount(mnemonic); count != expected {
+			t.Errorf("WordCount(%q): expected %d, got %d", mnemonic, expected, count)
+		}
+	}
+}
+
+func TestNewRandomMnemonicWithWords(t *testing.T) {
+	for _, words := range []int{12, 15, 18, 21, 24} {
+		mnemonic, err := NewRandomMnemonicWithWords(words)
+		if err != nil {
+			t.Fatalf("%d words: expected no error, got %v", words, err)
+		}
+		if count := WordCount(mnemonic); count != words {
+			t.Errorf("%d words: got %d", words, count)
+		}
+
+		// Address generation accepts every valid length
+		if _, err := GenerateBTCAddress(mnemonic); err != nil {
+			t.Errorf("%d words: expected an address, got %v", words, err)
+		}
+	}
+
+	for _, words := range []int{0, 11, 13, 27} {
+		if _, err := NewRandomMnemonicWithWords(words); err == nil {
+			t.Errorf("Expected an error for %d words", words)
+		}
+	}
+}
+
+func TestMnemonicStrength(t *testing.T) {
+	for _, bits := range []int{128, 160, 192, 224, 256} {
+		mnemonic, err := EntropyToMnemonic(make([]byte, bits/8))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		strength, err := MnemonicStrength(mnemonic)
+		if err != nil {
+			t.Fatalf("%d bits: expected no error, got %v", bits, err)
+		}
+		if strength != bits {
+			t.Errorf("Expected %d bits, got %d", bits, strength)
+		}
+	}
+
+	for _, mnemonic := range []string{"", "abandon ability able", strings.Repeat("abandon ", 13)} {
+		if _, err := MnemonicStrength(mnemonic); err == nil {
+			t.Errorf("Expected an error for %q", mnemonic)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"golang.org/x/crypto/sha3"
+)
+
+// GenerateETHAddress generates an EIP-55 checksummed Ethereum address from a
+// BIP39 mnemonic using the path m/44'/60'/0'/0/0.
+func GenerateETHAddress(mnemonic string) (string, error) {
+	return deriveAddress(derivers["eth"], mnemonic, childPath(externalChain(44, 60), 0)) // Coin type: 60 for Ethereum
+}
+
+// ethAddress encodes the public key of an extended key as an EIP-55
+// checksummed Ethereum address
+func ethAddress(key *hdkeychain.ExtendedKey) (string, error) {
+	pubKey, err := key.ECPubKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to get public key: %v", err)
+	}
+
+	// The address is the last 20 bytes of the Keccak-256 hash of the
+	// uncompressed public key without its 0x04 prefix
+	hash := keccak256(pubKey.SerializeUncompressed()[1:])
+	return eip55Checksum(hash[12:]), nil
+}
+
+// keccak256 returns the legacy Keccak-256 hash used by Ethereum
+func keccak256(data []byte) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(data)
+	return hasher.Sum(nil)
+}
+
+// eip55Checksum encodes a 20-byte address as 0x-prefixed hex, upper-casing
+// each letter whose corresponding nibble in the hash of the lowercase hex is
+// 8 or more (EIP-55)
+func eip55Checksum(address []byte) string {
+	lower := hex.EncodeToString(address)
+	hash := keccak256([]byte(lower))
+
+	var sb strings.Builder
+	sb.WriteString("0x")
+	for i, c := range lower {
+		nibble := hash[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if c >= 'a' && nibble&0x0f >= 8 {
+			c -= 'a' - 'A'
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
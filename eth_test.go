@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestGenerateETHAddress(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	expectedAddress := "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"
+
+	address, err := GenerateETHAddress(mnemonic)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if address != expectedAddress {
+		t.Errorf("Expected address %s, got %s", expectedAddress, address)
+	}
+}
+
+func TestGenerateETHAddress_InvalidMnemonic(t *testing.T) {
+	address, err := GenerateETHAddress("invalid mnemonic phrase")
+	if err == nil {
+		t.Fatalf("Expected an error for invalid mnemonic, got address %s", address)
+	}
+}
+
+func TestEIP55Checksum(t *testing.T) {
+	// Test vectors from EIP-55
+	vectors := []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	}
+
+	for _, vector := range vectors {
+		raw, err := hex.DecodeString(strings.ToLower(vector[2:]))
+		if err != nil {
+			t.Fatalf("Failed to decode %s: %v", vector, err)
+		}
+
+		if address := eip55Checksum(raw); address != vector {
+			t.Errorf("Expected %s, got %s", vector, address)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxFilterLineLength bounds the length of a candidate line. Real phrases
+// are a few hundred bytes at most, but dumps sometimes carry long junk lines
+// that would overflow bufio.Scanner's 64 KiB default.
+const maxFilterLineLength = 1 << 20
+
+// Stats counts what FilterValidMnemonicsWithStats has processed
+type Stats struct {
+	Lines   int   // lines read, including blank ones
+	Valid   int   // valid mnemonics written out
+	Invalid int   // non-blank lines that are not valid mnemonics
+	Bytes   int64 // bytes read from the input
+}
+
+// FilterValidMnemonics reads candidate phrases from r, one per line, and
+// copies the valid BIP39 mnemonics to w. Blank lines are ignored. It returns
+// how many valid and invalid phrases were seen.
+func FilterValidMnemonics(r io.Reader, w io.Writer) (valid, invalid int, err error) {
+	stats, err := FilterValidMnemonicsWithStats(r, w)
+	return stats.Valid, stats.Invalid, err
+}
+
+// FilterValidMnemonicsWithStats is FilterValidMnemonics reporting full
+// Stats, for tracking throughput on multi-gigabyte candidate dumps. Lines of
+// up to 1 MiB are accepted. Phrases are checked against the loaded wordlist,
+// see UseWordlist. On a read error the valid phrases found so far are still
+// written out and the stats cover the input processed so far.
+func FilterValidMnemonicsWithStats(r io.Reader, w io.Writer) (Stats, error) {
+	var stats Stats
+	counter := &countingReader{r: r}
+	scanner := bufio.NewScanner(counter)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxFilterLineLength)
+	out := bufio.NewWriter(w)
+
+	for scanner.Scan() {
+		stats.Lines++
+		phrase := strings.TrimSpace(scanner.Text())
+		if phrase == "" {
+			continue
+		}
+
+		if !IsMnemonicValid(phrase) {
+			stats.Invalid++
+			continue
+		}
+
+		stats.Valid++
+		if _, err := fmt.Fprintln(out, phrase); err != nil {
+			stats.Bytes = counter.n
+			return stats, fmt.Errorf("error writing mnemonic: %v", err)
+		}
+	}
+	stats.Bytes = counter.n
+
+	// Flush before reporting a read error so accepted phrases are not lost
+	if err := out.Flush(); err != nil {
+		return stats, fmt.Errorf("error writing mnemonic: %v", err)
+	}
+	if err := scanner.Err(); err != nil {
+		return stats, fmt.Errorf("error reading mnemonics: %v", err)
+	}
+
+	return stats, nil
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+)
+
+func TestFilterValidMnemonics(t *testing.T) {
+	input := strings.Join([]string{
+		"mother author steel speak help absurd feature flee photo distance broken long",
+		"invalid mnemonic phrase",
+		"",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		"  abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about  ",
+	}, "\n")
+
+	var out bytes.Buffer
+	valid, invalid, err := FilterValidMnemonics(strings.NewReader(input), &out)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if valid != 2 || invalid != 2 {
+		t.Errorf("Expected 2 valid and 2 invalid, got %d and %d", valid, invalid)
+	}
+
+	expected := "mother author steel speak help absurd feature flee photo distance broken long\n" +
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about\n"
+	if out.String() != expected {
+		t.Errorf("Expected output %q, got %q", expected, out.String())
+	}
+}
+
+func TestFilterValidMnemonicsWithStats(t *testing.T) {
+	input := strings.Join([]string{
+		"mother author steel speak help absurd feature flee photo distance broken long",
+		"",
+		strings.Repeat("x", 200*1024), // longer than bufio.Scanner's default limit
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	}, "\n") + "\n"
+
+	var out bytes.Buffer
+	stats, err := FilterValidMnemonicsWithStats(strings.NewReader(input), &out)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := Stats{Lines: 4, Valid: 2, Invalid: 1, Bytes: int64(len(input))}
+	if stats != expected {
+		t.Errorf("Expected %+v, got %+v", expected, stats)
+	}
+	if lines := strings.Count(out.String(), "\n"); lines != 2 {
+		t.Errorf("Expected 2 mnemonics written, got %d", lines)
+	}
+}
+
+func TestFilterValidMnemonicsWithStats_ReadError(t *testing.T) {
+	valid := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about\n"
+	r := io.MultiReader(strings.NewReader(valid), iotest.ErrReader(errors.New("disk failure")))
+
+	var out bytes.Buffer
+	stats, err := FilterValidMnemonicsWithStats(r, &out)
+	if err == nil {
+		t.Fatalf("Expected the read error to be returned")
+	}
+	if stats.Valid != 1 || out.String() != valid {
+		t.Errorf("Expected the phrase read before the error to be written, got %+v and %q", stats, out.String())
+	}
+}
+
+func TestFilterValidMnemonics_LoadedWordlist(t *testing.T) {
+	defer SetLanguage("english")
+
+	english := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	italian, err := TranslateMnemonic(english, wordlists.English, wordlists.Italian)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	UseWordlist("", wordlists.Italian)
+
+	var out bytes.Buffer
+	valid, invalid, err := FilterValidMnemonics(strings.NewReader(english+"\n"+italian+"\n"), &out)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if valid != 1 || invalid != 1 || out.String() != italian+"\n" {
+		t.Errorf("Expected only the Italian phrase to pass, got %d valid, %d invalid and %q", valid, invalid, out.String())
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+// Generator is a resettable mnemonicGenerator. Its Next method value can be
+// passed wherever a phrase generator function is expected, e.g. to Take or
+// RunFor, and Reset rewinds it for another pass over the same phrases.
+type Generator struct {
+	start     []int
+	wordCount int
+	mode      GeneratorMode
+	next      func() ([]string, bool)
+}
+
+// NewGenerator returns a Generator yielding the same phrases as
+// mnemonicGenerator with these arguments
+func NewGenerator(startIndices []int, wordCount int, mode GeneratorMode) (*Generator, error) {
+	// Copied so later changes to startIndices by the caller do not move
+	// the point Reset returns to
+	start := append([]int(nil), startIndices...)
+
+	next, err := mnemonicGenerator(start, wordCount, mode)
+	if err != nil {
+		return nil, err
+	}
+	return &Generator{start: start, wordCount: wordCount, mode: mode, next: next}, nil
+}
+
+// Next returns the next phrase and whether more follow. Once the generator
+// is exhausted it returns nil and false until Reset is called.
+func (g *Generator) Next() ([]string, bool) {
+	return g.next()
+}
+
+// Reset rewinds the generator to its initial start indices
+func (g *Generator) Reset() {
+	// The arguments were validated by NewGenerator, so this only fails if
+	// BIP39Words has since been replaced by a shorter list; the generator is
+	// then left exhausted
+	next, err := mnemonicGenerator(g.start, g.wordCount, g.mode)
+	if err != nil {
+		next = func() ([]string, bool) { return nil, false }
+	}
+	g.next = next
+}
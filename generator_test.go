@@ -0,0 +1,77 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerator_Reset(t *testing.T) {
+	useTestWords(t, []string{"abandon", "ability", "able", "about", "above"})
+
+	tests := []struct {
+		name      string
+		start     []int
+		wordCount int
+		mode      GeneratorMode
+		expected  int
+	}{
+		{"combinations", nil, 3, Combinations, 10},
+		{"combinations from start", []int{1, 2, 3}, 3, Combinations, 4},
+		{"with repetition", nil, 2, WithRepetition, 25},
+	}
+
+	for _, tt := range tests {
+		gen, err := NewGenerator(tt.start, tt.wordCount, tt.mode)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.name, err)
+		}
+
+		first := Take(gen.Next, 100)
+		if len(first) != tt.expected {
+			t.Fatalf("%s: expected %d phrases, got %d", tt.name, tt.expected, len(first))
+		}
+		if phrase, more := gen.Next(); phrase != nil || more {
+			t.Errorf("%s: expected the generator to be exhausted, got %v", tt.name, phrase)
+		}
+
+		gen.Reset()
+		second := Take(gen.Next, 100)
+		if len(second) != len(first) {
+			t.Fatalf("%s: expected %d phrases after Reset, got %d", tt.name, len(first), len(second))
+		}
+		for i := range first {
+			if strings.Join(first[i], " ") != strings.Join(second[i], " ") {
+				t.Errorf("%s: phrase %d: expected %v after Reset, got %v", tt.name, i, first[i], second[i])
+			}
+		}
+	}
+}
+
+func TestGenerator_ResetMidway(t *testing.T) {
+	useTestWords(t, []string{"abandon", "ability", "able", "about", "above"})
+
+	start := []int{0, 1, 4}
+	gen, err := NewGenerator(start, 3, Combinations)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	start[2] = 2 // must not affect the generator
+
+	gen.Next()
+	gen.Next()
+	gen.Reset()
+	if phrase, _ := gen.Next(); strings.Join(phrase, " ") != "abandon ability above" {
+		t.Errorf("Expected Reset to return to the start, got %v", phrase)
+	}
+}
+
+func TestNewGenerator_Invalid(t *testing.T) {
+	useTestWords(t, []string{"abandon", "ability", "able", "about", "above"})
+
+	if _, err := NewGenerator([]int{2, 1, 0}, 3, Combinations); err == nil {
+		t.Errorf("Expected an error for descending start indices")
+	}
+	if _, err := NewGenerator(nil, 0, Combinations); err == nil {
+		t.Errorf("Expected an error for a zero word count")
+	}
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// SLIP-0132 mainnet version bytes of extended keys, which select the base58
+// prefix and tell wallets which script type the account uses
+var (
+	xpubVersion = []byte{0x04, 0x88, 0xb2, 0x1e} // BIP44 P2PKH
+	xprvVersion = []byte{0x04, 0x88, 0xad, 0xe4}
+	ypubVersion = []byte{0x04, 0x9d, 0x7c, 0xb2} // BIP49 P2SH-P2WPKH
+	yprvVersion = []byte{0x04, 0x9d, 0x78, 0x78}
+	zpubVersion = []byte{0x04, 0xb2, 0x47, 0x46} // BIP84 P2WPKH
+	zprvVersion = []byte{0x04, 0xb2, 0x43, 0x0c}
+)
+
+// SLIP-0132 testnet version bytes, the counterparts of the mainnet ones
+var (
+	tpubVersion = []byte{0x04, 0x35, 0x87, 0xcf} // BIP44 P2PKH
+	tprvVersion = []byte{0x04, 0x35, 0x83, 0x94}
+	upubVersion = []byte{0x04, 0x4a, 0x52, 0x62} // BIP49 P2SH-P2WPKH
+	uprvVersion = []byte{0x04, 0x4a, 0x4e, 0x28}
+	vpubVersion = []byte{0x04, 0x5f, 0x1c, 0xf6} // BIP84 P2WPKH
+	vprvVersion = []byte{0x04, 0x5f, 0x18, 0xbc}
+)
+
+// publicKeyVersions maps a BIP purpose to the mainnet version bytes of its
+// extended public keys. Purposes without an entry use the BIP32 xpub version.
+var publicKeyVersions = map[uint32][]byte{
+	44: xpubVersion,
+	49: ypubVersion,
+	84: zpubVersion,
+}
+
+// privateKeyVersions maps a BIP purpose to the mainnet version bytes of its
+// extended private keys, mirroring publicKeyVersions.
+var privateKeyVersions = map[uint32][]byte{
+	44: xprvVersion,
+	49: yprvVersion,
+	84: zprvVersion,
+}
+
+// testnetPublicKeyVersions is publicKeyVersions for testnet keys
+var testnetPublicKeyVersions = map[uint32][]byte{
+	44: tpubVersion,
+	49: upubVersion,
+	84: vpubVersion,
+}
+
+// testnetPrivateKeyVersions is privateKeyVersions for testnet keys
+var testnetPrivateKeyVersions = map[uint32][]byte{
+	44: tprvVersion,
+	49: uprvVersion,
+	84: vprvVersion,
+}
+
+// EncodeExtendedKey base58-encodes key with the SLIP-0132 version bytes
+// matching purpose on the key's network: xpub/xprv for 44, ypub/yprv for 49
+// and zpub/zprv for 84 on mainnet, and tpub/tprv, upub/uprv and vpub/vprv on
+// testnet, public or private as the key is. Other purposes, such as BIP86
+// Taproot, use the plain BIP32 versions. Keys carrying any other version are
+// rejected. The re-versioned copy shares key material with key, so zeroing
+// key remains the caller's job.
+func EncodeExtendedKey(key *hdkeychain.ExtendedKey, purpose uint32) (string, error) {
+	if key == nil {
+		return "", fmt.Errorf("extended key is nil")
+	}
+
+	versions, err := extendedKeyVersions(key)
+	if err != nil {
+		return "", err
+	}
+	version, ok := versions[purpose]
+	if !ok {
+		version = versions[44]
+	}
+
+	encoded, err := key.CloneWithVersion(version)
+	if err != nil {
+		return "", fmt.Errorf("failed to set extended key version: %v", err)
+	}
+	return encoded.String(), nil
+}
+
+// extendedKeyVersions returns the purpose-to-version table of key's network
+// and kind, public or private, recognising the network from any SLIP-0132
+// version the key already carries
+func extendedKeyVersions(key *hdkeychain.ExtendedKey) (map[uint32][]byte, error) {
+	mainnet, testnet := publicKeyVersions, testnetPublicKeyVersions
+	if key.IsPrivate() {
+		mainnet, testnet = privateKeyVersions, testnetPrivateKeyVersions
+	}
+
+	for _, versions := range []map[uint32][]byte{mainnet, testnet} {
+		for _, version := range versions {
+			if bytes.Equal(key.Version(), version) {
+				return versions, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("extended key version %x is not a mainnet or testnet version", key.Version())
+}
+
+// MasterFingerprint returns the BIP32 fingerprint of a mnemonic's master key:
+// the first 4 bytes of the HASH160 of the master public key, as 8 hex
+// characters. It labels key origins in output descriptors such as
+// [73c5da0a/84'/0'/0']xpub...
+func MasterFingerprint(mnemonic string) (string, error) {
+	masterKey, err := deriveKey(mnemonic, "", nil, &chaincfg.MainNetParams)
+	if err != nil {
+		return "", err
+	}
+	defer masterKey.Zero()
+
+	pubKey, err := masterKey.ECPubKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to get public key: %v", err)
+	}
+
+	return hex.EncodeToString(btcutil.Hash160(pubKey.SerializeCompressed())[:4]), nil
+}
+
+// MasterPrivateKey returns the 32-byte private scalar of the BIP32 master key
+// of a mnemonic and passphrase, for libraries that take the raw root secret.
+//
+// This is the root key of the whole wallet: every account and address of
+// every coin derives from it. Never log it, store it unencrypted or pass it
+// to untrusted software, and Zero the result once it has been handed over.
+func MasterPrivateKey(mnemonic, passphrase string) ([]byte, error) {
+	masterKey, err := deriveKey(mnemonic, passphrase, nil, &chaincfg.MainNetParams)
+	if err != nil {
+		return nil, err
+	}
+	defer masterKey.Zero()
+
+	privKey, err := masterKey.ECPrivKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get private key: %v", err)
+	}
+	defer privKey.Zero()
+
+	return privKey.Serialize(), nil
+}
+
+// AccountXPub returns the base58 extended public key of the account
+// m/purpose'/coin'/account' for a mnemonic. The key is neutered so it can be
+// shared to watch the account without exposing private keys, and encoded with
+// the xpub, ypub or zpub version bytes matching the purpose.
+func AccountXPub(mnemonic string, purpose, coin, account uint32) (string, error) {
+	return AccountXPubWithParams(mnemonic, purpose, coin, account, &chaincfg.MainNetParams)
+}
+
+// AccountXPubWithParams is AccountXPub for the network of params. Testnet
+// account keys are encoded as tpub, upub or vpub.
+func AccountXPubWithParams(mnemonic string, purpose, coin, account uint32, params *chaincfg.Params) (string, error) {
+	accountKey, err := deriveKey(mnemonic, "", []uint32{
+		hdkeychain.HardenedKeyStart + purpose,
+		hdkeychain.HardenedKeyStart + coin,
+		hdkeychain.HardenedKeyStart + account,
+	}, params)
+	if err != nil {
+		return "", err
+	}
+	// The neutered key shares the chain code, so wipe only once it is encoded
+	defer accountKey.Zero()
+
+	pubKey, err := accountKey.Neuter()
+	if err != nil {
+		return "", fmt.Errorf("failed to neuter account key: %v", err)
+	}
+
+	return EncodeExtendedKey(pubKey, purpose)
+}
+
+// networkPublicKeyVersions returns the SLIP-0132 public key versions of the
+// network of params: the mainnet table for mainnet, the testnet one for the
+// networks sharing the tpub version, and nil for any other network
+func networkPublicKeyVersions(params *chaincfg.Params) map[uint32][]byte {
+	switch {
+	case params.Net == chaincfg.MainNetParams.Net:
+		return publicKeyVersions
+	case bytes.Equal(params.HDPublicKeyID[:], tpubVersion):
+		return testnetPublicKeyVersions
+	}
+	return nil
+}
+
+// AddressesFromXPub derives count receiving addresses starting at index
+// start from an account extended public key such as AccountXPub returns,
+// without access to the seed. Addresses are the external chain children
+// m/.../0/i. The ypub and zpub versions, or upub and vpub on testnet, yield
+// nested and native SegWit addresses; any other key must carry the
+// network's own xpub version and
+// yields legacy P2PKH addresses. Extended private keys are rejected, as is a
+// range reaching the hardened indices, which a public key cannot derive.
+func AddressesFromXPub(xpub string, start, count uint32, params *chaincfg.Params) ([]string, error) {
+	if count == 0 {
+		return nil, fmt.Errorf("invalid address count: %d", count)
+	}
+	if IsHardened(start) || count > HardenedKeyStart-start {
+		return nil, fmt.Errorf("address range %d+%d reaches hardened indices", start, count)
+	}
+
+	accountKey, err := hdkeychain.NewKeyFromString(xpub)
+	if err != nil {
+		return nil, fmt.Errorf("invalid extended public key: %v", err)
+	}
+	if accountKey.IsPrivate() {
+		return nil, fmt.Errorf("extended key is private, expected a public key")
+	}
+
+	version := accountKey.Version()
+	versions := networkPublicKeyVersions(params)
+	var kind AddressKind
+	switch {
+	case bytes.Equal(version, params.HDPublicKeyID[:]):
+		kind = P2PKH
+	case versions != nil && bytes.Equal(version, versions[49]):
+		kind = P2SH
+	case versions != nil && bytes.Equal(version, versions[84]):
+		kind = P2WPKH
+	default:
+		return nil, fmt.Errorf("extended public key version %x is not for network %s", version, params.Name)
+	}
+
+	chainKey, err := accountKey.Derive(0) // Change: 0 for external chain
+	if err != nil {
+		return nil, ErrDerivation{Depth: 1, Err: err}
+	}
+
+	addresses := make([]string, 0, count)
+	for i := uint32(0); i < count; i++ {
+		childKey, err := chainKey.Derive(start + i)
+		if err != nil {
+			return addresses, ErrDerivation{Depth: 2, Err: err}
+		}
+
+		address, err := encodeAddress(childKey, kind, params)
+		if err != nil {
+			return addresses, fmt.Errorf("%w: %v", ErrAddressEncode, err)
+		}
+		addresses = append(addresses, address)
+	}
+
+	return addresses, nil
+}
+
+// AccountXPrv returns the base58 extended private key of the account
+// m/purpose'/coin'/account' for a mnemonic and passphrase, encoded with the
+// xprv, yprv or zprv version bytes matching the purpose.
+//
+// The result controls every address of the account: never log it, store it
+// unencrypted or pass it to untrusted software.
+func AccountXPrv(mnemonic, passphrase string, purpose, coin, account uint32) (string, error) {
+	accountKey, err := deriveKey(mnemonic, passphrase, []uint32{
+		hdkeychain.HardenedKeyStart + purpose,
+		hdkeychain.HardenedKeyStart + coin,
+		hdkeychain.HardenedKeyStart + account,
+	}, &chaincfg.MainNetParams)
+	if err != nil {
+		return "", err
+	}
+	defer accountKey.Zero()
+
+	return EncodeExtendedKey(accountKey, purpose)
+}
+
+// PrivateKeyWIF returns the private key at path for a mnemonic, encoded as a
+// compressed-pubkey WIF string for the given network.
+//
+// The result controls the funds of the derived address: never log it, store
+// it unencrypted or pass it to untrusted software. Intermediate key material
+// is zeroed once encoded, but Go gives no guarantee that no other copies
+// remain in memory.
+func PrivateKeyWIF(mnemonic string, path []uint32, params *chaincfg.Params) (string, error) {
+	childKey, err := deriveKey(mnemonic, "", path, params)
+	if err != nil {
+		return "", err
+	}
+	defer childKey.Zero()
+
+	privKey, err := childKey.ECPrivKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to get private key: %v", err)
+	}
+	defer privKey.Zero()
+
+	wif, err := btcutil.NewWIF(privKey, params, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode private key: %v", err)
+	}
+
+	return wif.String(), nil
+}
@@ -0,0 +1,417 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestMasterFingerprint(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	expected := "73c5da0a"
+
+	fingerprint, err := MasterFingerprint(mnemonic)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if fingerprint != expected {
+		t.Errorf("Expected fingerprint %s, got %s", expected, fingerprint)
+	}
+}
+
+func TestMasterFingerprint_InvalidMnemonic(t *testing.T) {
+	if _, err := MasterFingerprint("invalid mnemonic phrase"); err == nil {
+		t.Fatalf("Expected an error for invalid mnemonic")
+	}
+}
+
+func TestMasterPrivateKey(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	// The private key of the BIP32 root xprv9s21ZrQH143K3GJpoapnV8SFfukcVBSfeCficPSGfubmSFDxo1kuHnLisriDvSnRRuL2Qrg5ggqHKNVpxR86QEC8w35uxmGoggxtQTPvfUu
+	expected := "1837c1be8e2995ec11cda2b066151be2cfb48adf9e47b151d46adab3a21cdf67"
+
+	key, err := MasterPrivateKey(mnemonic, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := hex.EncodeToString(key); got != expected {
+		t.Errorf("Expected master private key %s, got %s", expected, got)
+	}
+
+	withPassphrase, err := MasterPrivateKey(mnemonic, "TREZOR")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if hex.EncodeToString(withPassphrase) == expected {
+		t.Errorf("Expected a passphrase to change the master private key")
+	}
+
+	if _, err := MasterPrivateKey("invalid mnemonic phrase", ""); err == nil {
+		t.Fatalf("Expected an error for invalid mnemonic")
+	}
+}
+
+func TestAccountXPub(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	// Account keys from the BIP44/49/84 test vectors
+	tests := []struct {
+		purpose  uint32
+		expected string
+	}{
+		{44, "xpub6BosfCnifzxcFwrSzQiqu2DBVTshkCXacvNsWGYJVVhhawA7d4R5WSWGFNbi8Aw6ZRc1brxMyWMzG3DSSSSoekkudhUd9yLb6qx39T9nMdj"},
+		{49, "ypub6Ww3ibxVfGzLrAH1PNcjyAWenMTbbAosGNB6VvmSEgytSER9azLDWCxoJwW7Ke7icmizBMXrzBx9979FfaHxHcrArf3zbeJJJUZPf663zsP"},
+		{84, "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs"},
+	}
+
+	for _, tt := range tests {
+		xpub, err := AccountXPub(mnemonic, tt.purpose, 0, 0)
+		if err != nil {
+			t.Fatalf("Purpose %d: expected no error, got %v", tt.purpose, err)
+		}
+		if xpub != tt.expected {
+			t.Errorf("Purpose %d: expected %s, got %s", tt.purpose, tt.expected, xpub)
+		}
+	}
+}
+
+func TestAddressesFromXPub(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	xpub, err := AccountXPub(mnemonic, 44, 0, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected, err := GenerateBTCAddresses(mnemonic, 4)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	addresses, err := AddressesFromXPub(xpub, 1, 3, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(addresses) != 3 {
+		t.Fatalf("Expected 3 addresses, got %d", len(addresses))
+	}
+	for i, address := range addresses {
+		if address != expected[i+1] {
+			t.Errorf("Expected address %d to be %s, got %s", i+1, expected[i+1], address)
+		}
+	}
+
+	// A zpub yields native SegWit addresses
+	zpub, err := AccountXPub(mnemonic, 84, 0, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	addresses, err = AddressesFromXPub(zpub, 0, 1, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if addresses[0] != "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu" {
+		t.Errorf("Expected bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu, got %s", addresses[0])
+	}
+}
+
+func TestAddressesFromXPub_Testnet(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	params := &chaincfg.TestNet3Params
+
+	tests := []struct {
+		purpose uint32
+		prefix  string
+		kind    AddressKind
+	}{
+		{44, "tpub", P2PKH},
+		{49, "upub", P2SH},
+		{84, "vpub", P2WPKH},
+	}
+
+	for _, tt := range tests {
+		xpub, err := AccountXPubWithParams(mnemonic, tt.purpose, 1, 0, params)
+		if err != nil {
+			t.Fatalf("Purpose %d: expected no error, got %v", tt.purpose, err)
+		}
+		if !strings.HasPrefix(xpub, tt.prefix) {
+			t.Errorf("Purpose %d: expected prefix %s, got %s", tt.purpose, tt.prefix, xpub)
+		}
+
+		addresses, err := AddressesFromXPub(xpub, 0, 2, params)
+		if err != nil {
+			t.Fatalf("Purpose %d: expected no error, got %v", tt.purpose, err)
+		}
+
+		// The addresses match those derived from the seed
+		for i, address := range addresses {
+			key, err := deriveKey(mnemonic, "", []uint32{Hardened(tt.purpose), Hardened(1), Hardened(0), 0, uint32(i)}, params)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			expected, err := encodeAddress(key, tt.kind, params)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if address != expected {
+				t.Errorf("Purpose %d: expected address %d to be %s, got %s", tt.purpose, i, expected, address)
+			}
+		}
+
+		// A testnet key is not accepted for mainnet
+		if _, err := AddressesFromXPub(xpub, 0, 1, &chaincfg.MainNetParams); err == nil {
+			t.Errorf("Purpose %d: expected an error for a testnet key on mainnet", tt.purpose)
+		}
+	}
+}
+
+func TestAddressesFromXPub_Invalid(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	xpub, err := AccountXPub(mnemonic, 44, 0, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	xprv, err := AccountXPrv(mnemonic, "", 44, 0, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		key    string
+		start  uint32
+		count  uint32
+		params *chaincfg.Params
+	}{
+		{"private key", xprv, 0, 1, &chaincfg.MainNetParams},
+		{"hardened start", xpub, hdkeychain.HardenedKeyStart, 1, &chaincfg.MainNetParams},
+		{"range into hardened", xpub, hdkeychain.HardenedKeyStart - 1, 2, &chaincfg.MainNetParams},
+		{"zero count", xpub, 0, 0, &chaincfg.MainNetParams},
+		{"wrong network", xpub, 0, 1, &chaincfg.TestNet3Params},
+		{"not a key", "xpub-garbage", 0, 1, &chaincfg.MainNetParams},
+	}
+
+	for _, tt := range tests {
+		if _, err := AddressesFromXPub(tt.key, tt.start, tt.count, tt.params); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestAccountXPrv(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	// Account keys from the BIP44/49/84 test vectors
+	tests := []struct {
+		purpose  uint32
+		expected string
+	}{
+		{44, "xprv9xpXFhFpqdQK3TmytPBqXtGSwS3DLjojFhTGht8gwAAii8py5X6pxeBnQ6ehJiyJ6nDjWGJfZ95WxByFXVkDxHXrqu53WCRGypk2ttuqncb"},
+		{49, "yprvAHwhK6RbpuS3dgCYHM5jc2ZvEKd7Bi61u9FVhYMpgMSuZS613T1xxQeKTffhrHY79hZ5PsskBjcc6C2V7DrnsMsNaGDaWev3GLRQRgV7hxF"},
+		{84, "zprvAdG4iTXWBoARxkkzNpNh8r6Qag3irQB8PzEMkAFeTRXxHpbF9z4QgEvBRmfvqWvGp42t42nvgGpNgYSJA9iefm1yYNZKEm7z6qUWCroSQnE"},
+	}
+
+	for _, tt := range tests {
+		xprv, err := AccountXPrv(mnemonic, "", tt.purpose, 0, 0)
+		if err != nil {
+			t.Fatalf("Purpose %d: expected no error, got %v", tt.purpose, err)
+		}
+		if xprv != tt.expected {
+			t.Errorf("Purpose %d: expected %s, got %s", tt.purpose, tt.expected, xprv)
+		}
+	}
+}
+
+func TestEncodeExtendedKey(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	// The BIP84 account key, re-encoded under each purpose
+	accountKey, err := deriveKey(mnemonic, "", []uint32{Hardened(84), Hardened(0), Hardened(0)}, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	pubKey, err := accountKey.Neuter()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		key     *hdkeychain.ExtendedKey
+		purpose uint32
+		prefix  string
+		version []byte
+	}{
+		{pubKey, 44, "xpub", xpubVersion},
+		{pubKey, 49, "ypub", ypubVersion},
+		{pubKey, 84, "zpub", zpubVersion},
+		{pubKey, 86, "xpub", xpubVersion},
+		{accountKey, 44, "xprv", xprvVersion},
+		{accountKey, 49, "yprv", yprvVersion},
+		{accountKey, 84, "zprv", zprvVersion},
+	}
+
+	for _, tt := range tests {
+		encoded, err := EncodeExtendedKey(tt.key, tt.purpose)
+		if err != nil {
+			t.Fatalf("Purpose %d: expected no error, got %v", tt.purpose, err)
+		}
+		if !strings.HasPrefix(encoded, tt.prefix) {
+			t.Errorf("Purpose %d: expected prefix %s, got %s", tt.purpose, tt.prefix, encoded)
+		}
+
+		decoded, err := hdkeychain.NewKeyFromString(encoded)
+		if err != nil {
+			t.Fatalf("Purpose %d: expected the result to decode, got %v", tt.purpose, err)
+		}
+		if !bytes.Equal(decoded.Version(), tt.version) {
+			t.Errorf("Purpose %d: expected version %x, got %x", tt.purpose, tt.version, decoded.Version())
+		}
+	}
+
+	// Only the version changes, so the BIP84 vector comes back unchanged
+	zpub, err := EncodeExtendedKey(pubKey, 84)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if expected := "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs"; zpub != expected {
+		t.Errorf("Expected %s, got %s", expected, zpub)
+	}
+
+	if _, err := EncodeExtendedKey(nil, 44); err == nil {
+		t.Errorf("Expected an error for a nil key")
+	}
+}
+
+func TestEncodeExtendedKey_Testnet(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	accountKey, err := deriveKey(mnemonic, "", []uint32{Hardened(84), Hardened(1), Hardened(0)}, &chaincfg.TestNet3Params)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	pubKey, err := accountKey.Neuter()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		key     *hdkeychain.ExtendedKey
+		purpose uint32
+		prefix  string
+		version []byte
+	}{
+		{pubKey, 44, "tpub", tpubVersion},
+		{pubKey, 49, "upub", upubVersion},
+		{pubKey, 84, "vpub", vpubVersion},
+		{pubKey, 86, "tpub", tpubVersion},
+		{accountKey, 44, "tprv", tprvVersion},
+		{accountKey, 49, "uprv", uprvVersion},
+		{accountKey, 84, "vprv", vprvVersion},
+	}
+
+	for _, tt := range tests {
+		encoded, err := EncodeExtendedKey(tt.key, tt.purpose)
+		if err != nil {
+			t.Fatalf("Purpose %d: expected no error, got %v", tt.purpose, err)
+		}
+		if !strings.HasPrefix(encoded, tt.prefix) {
+			t.Errorf("Purpose %d: expected prefix %s, got %s", tt.purpose, tt.prefix, encoded)
+		}
+
+		decoded, err := hdkeychain.NewKeyFromString(encoded)
+		if err != nil {
+			t.Fatalf("Purpose %d: expected the result to decode, got %v", tt.purpose, err)
+		}
+		if !bytes.Equal(decoded.Version(), tt.version) {
+			t.Errorf("Purpose %d: expected version %x, got %x", tt.purpose, tt.version, decoded.Version())
+		}
+	}
+
+	// A key already carrying a SLIP-0132 testnet version stays on testnet
+	vpub, err := EncodeExtendedKey(pubKey, 84)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	decoded, err := hdkeychain.NewKeyFromString(vpub)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	tpub, err := EncodeExtendedKey(decoded, 44)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(tpub, "tpub") {
+		t.Errorf("Expected a tpub, got %s", tpub)
+	}
+
+	// Keys of other networks are rejected rather than re-encoded for mainnet
+	simnetKey, err := deriveKey(mnemonic, "", nil, &chaincfg.SimNetParams)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := EncodeExtendedKey(simnetKey, 84); err == nil {
+		t.Errorf("Expected an error for a simnet key")
+	}
+}
+
+func TestAccountXPrv_InvalidMnemonic(t *testing.T) {
+	if _, err := AccountXPrv("invalid mnemonic phrase", "", 44, 0, 0); err == nil {
+		t.Fatalf("Expected an error for invalid mnemonic")
+	}
+}
+
+func TestAccountXPub_InvalidMnemonic(t *testing.T) {
+	if _, err := AccountXPub("invalid mnemonic phrase", 44, 0, 0); err == nil {
+		t.Fatalf("Expected an error for invalid mnemonic")
+	}
+}
+
+func TestPrivateKeyWIF(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	// First receiving key from the BIP84 test vector
+	path := []uint32{hdkeychain.HardenedKeyStart + 84, hdkeychain.HardenedKeyStart, hdkeychain.HardenedKeyStart, 0, 0}
+	expected := "KyZpNDKnfs94vbrwhJneDi77V6jF64PWPF8x5cdJb8ifgg2DUc9d"
+
+	wif, err := PrivateKeyWIF(mnemonic, path, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if wif != expected {
+		t.Errorf("Expected WIF %s, got %s", expected, wif)
+	}
+}
+
+func TestPrivateKeyWIF_MatchesAddress(t *testing.T) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+	path := []uint32{hdkeychain.HardenedKeyStart + 44, hdkeychain.HardenedKeyStart, hdkeychain.HardenedKeyStart, 0, 0}
+
+	wif, err := PrivateKeyWIF(mnemonic, path, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	decoded, err := btcutil.DecodeWIF(wif)
+	if err != nil {
+		t.Fatalf("Failed to decode WIF: %v", err)
+	}
+	if !decoded.CompressPubKey {
+		t.Errorf("Expected a compressed WIF")
+	}
+
+	// The WIF's public key controls the BIP44 address
+	address, err := btcutil.NewAddressPubKey(decoded.SerializePubKey(), &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("Failed to create address: %v", err)
+	}
+	if address.EncodeAddress() != "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD" {
+		t.Errorf("Expected address %s, got %s", "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD", address.EncodeAddress())
+	}
+}
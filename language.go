@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	bip39 "github.com/tyler-smith/go-bip39"
+	"github.com/tyler-smith/go-bip39/wordlists"
+)
+
+// ideographicSpace separates the words of a Japanese mnemonic
+const ideographicSpace = "\u3000"
+
+// languages maps the supported language names to their BIP39 wordlists.
+// Portuguese is not built in, as the bip39 library ships no list for it; load
+// the official portuguese.txt with ReadBIP39 and UseWordlist instead.
+var languages = map[string][]string{
+	"chinese_simplified":  wordlists.ChineseSimplified,
+	"chinese_traditional": wordlists.ChineseTraditional,
+	"czech":               wordlists.Czech,
+	"english":             wordlists.English,
+	"french":              wordlists.French,
+	"italian":             wordlists.Italian,
+	"japanese":            wordlists.Japanese,
+	"korean":              wordlists.Korean,
+	"spanish":             wordlists.Spanish,
+}
+
+// currentLanguage is the language selected with SetLanguage, or "" for a
+// wordlist of unknown language loaded with UseWordlist
+var currentLanguage = "english"
+
+// Languages returns the names of the supported wordlist languages, sorted
+func Languages() []string {
+	names := make([]string, 0, len(languages))
+	for name := range languages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DetectLanguage returns the name of the built-in language whose wordlist is
+// exactly words, or "" if there is none, e.g. for a list loaded from a file
+func DetectLanguage(words []string) string {
+	for name, list := range languages {
+		if slices.Equal(words, list) {
+			return name
+		}
+	}
+	return ""
+}
+
+// SetLanguage selects the wordlist used for generating, validating and
+// displaying mnemonics
+func SetLanguage(name string) error {
+	words, ok := languages[name]
+	if !ok {
+		return fmt.Errorf("unsupported language %q, expected one of %s", name, strings.Join(Languages(), ", "))
+	}
+
+	UseWordlist(name, words)
+	return nil
+}
+
+// UseWordlist makes words, e.g. a list read with ReadBIP39, the wordlist used
+// for generating, validating and displaying mnemonics. It sets BIP39Words and
+// the bip39 library's list together, so checksum validation and address
+// derivation check phrases against the same words the generator produces.
+// language selects the word separator; pass "" for a space-separated list.
+func UseWordlist(language string, words []string) {
+	BIP39Words = words
+	bip39.SetWordList(words)
+	currentLanguage = language
+}
+
+// wordSeparator returns the separator used between the words of a mnemonic
+// in the given language
+func wordSeparator(language string) string {
+	if language == "japanese" {
+		return ideographicSpace
+	}
+	return " "
+}
+
+// FormatMnemonic joins mnemonic words for display using the separator of the
+// selected language
+func FormatMnemonic(words []string) string {
+	return JoinMnemonic(words, "")
+}
+
+// JoinMnemonic joins mnemonic words for display with sep. An empty sep picks
+// the separator of the selected language: a single space, or the ideographic
+// space U+3000 for Japanese.
+func JoinMnemonic(words []string, sep string) string {
+	if sep == "" {
+		sep = wordSeparator(currentLanguage)
+	}
+	return strings.Join(words, sep)
+}
+
+// TranslateMnemonic re-expresses a mnemonic written with the from wordlist
+// in the to wordlist. Both encode the same entropy, so the translated phrase
+// produces a wallet with the same keys once the target wallet decodes it.
+// The source phrase must be a valid BIP39 phrase length made of words from
+// from and carry a valid checksum.
+func TranslateMnemonic(mnemonic string, from, to []string) (string, error) {
+	if len(from) != BIP39WordlistSize || len(to) != BIP39WordlistSize {
+		return "", fmt.Errorf("translation requires %d-word wordlists, got %d and %d", BIP39WordlistSize, len(from), len(to))
+	}
+
+	words := strings.Fields(mnemonic)
+	if !isValidWordCount(len(words)) {
+		return "", fmt.Errorf("invalid word count: %d, must be 12, 15, 18, 21 or 24", len(words))
+	}
+
+	positions := make(map[string]int, len(from))
+	for i, word := range from {
+		positions[word] = i
+	}
+
+	indices := make([]int, len(words))
+	for i, word := range words {
+		idx, ok := positions[word]
+		if !ok {
+			return "", fmt.Errorf("word %d (%q) is not in the source wordlist", i+1, word)
+		}
+		indices[i] = idx
+	}
+
+	// The indices carry the entropy and checksum bits, which are the same in
+	// every language, so re-encoding is a lookup of the same indices in to
+	if !hasValidChecksum(indices) {
+		return "", fmt.Errorf("invalid mnemonic checksum")
+	}
+
+	translated := make([]string, len(indices))
+	for i, idx := range indices {
+		translated[i] = to[idx]
+	}
+
+	separator := " "
+	if to[0] == wordlists.Japanese[0] {
+		separator = ideographicSpace
+	}
+	return strings.Join(translated, separator), nil
+}
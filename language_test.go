@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+)
+
+func TestSetLanguage(t *testing.T) {
+	defer SetLanguage("english")
+
+	for _, name := range Languages() {
+		if err := SetLanguage(name); err != nil {
+			t.Fatalf("Expected no error for %s, got %v", name, err)
+		}
+		if len(BIP39Words) != BIP39WordlistSize {
+			t.Errorf("%s: expected %d words, got %d", name, BIP39WordlistSize, len(BIP39Words))
+		}
+	}
+
+	if err := SetLanguage("klingon"); err == nil {
+		t.Fatalf("Expected an error for an unsupported language")
+	}
+}
+
+func TestUseWordlist(t *testing.T) {
+	defer SetLanguage("english")
+
+	english := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	italian, err := TranslateMnemonic(english, wordlists.English, wordlists.Italian)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// A list read from a file validates phrases in its own language
+	words, err := ReadBIP39(strings.NewReader(strings.Join(wordlists.Italian, "\n")))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	UseWordlist("", words)
+
+	if !IsMnemonicValid(italian) {
+		t.Errorf("Expected the Italian phrase %q to be valid", italian)
+	}
+	if IsMnemonicValid(english) {
+		t.Errorf("Expected the English phrase to be invalid with the Italian list")
+	}
+	if err := ValidateMnemonicDetailed(italian); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if _, err := GenerateBTCAddress(italian); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if got := FormatMnemonic([]string{"abaco", "abete"}); got != "abaco abete" {
+		t.Errorf("Expected a space-separated phrase, got %q", got)
+	}
+}
+
+func TestJapaneseMnemonic(t *testing.T) {
+	defer SetLanguage("english")
+
+	if err := SetLanguage("japanese"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	entropy := make([]byte, 16)
+	mnemonic, err := EntropyToMnemonic(entropy)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Japanese words are joined by an ideographic space
+	if strings.Count(mnemonic, ideographicSpace) != 11 || strings.Contains(mnemonic, " ") {
+		t.Errorf("Expected words separated by U+3000, got %q", mnemonic)
+	}
+	if !strings.HasPrefix(mnemonic, "あいこくしん"+ideographicSpace) {
+		t.Errorf("Expected mnemonic to start with あいこくしん, got %q", mnemonic)
+	}
+
+	roundTrip, err := MnemonicToEntropy(mnemonic)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !bytes.Equal(roundTrip, entropy) {
+		t.Errorf("Expected entropy %x, got %x", entropy, roundTrip)
+	}
+
+	if _, err := GenerateBTCAddress(mnemonic); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestJoinMnemonic(t *testing.T) {
+	defer SetLanguage("english")
+
+	words := []string{"abandon", "ability", "able"}
+	if got := JoinMnemonic(words, ""); got != "abandon ability able" {
+		t.Errorf("Expected a single space by default, got %q", got)
+	}
+	if got := JoinMnemonic(words, "-"); got != "abandon-ability-able" {
+		t.Errorf("Expected %q, got %q", "abandon-ability-able", got)
+	}
+
+	if err := SetLanguage("japanese"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	japanese := []string{"あいこくしん", "あいこくしん", "あおぞら"}
+	if got := JoinMnemonic(japanese, ""); got != "あいこくしん\u3000あいこくしん\u3000あおぞら" {
+		t.Errorf("Expected words separated by U+3000, got %q", got)
+	}
+	if got := JoinMnemonic(japanese, " "); got != "あいこくしん あいこくしん あおぞら" {
+		t.Errorf("Expected an explicit separator to win, got %q", got)
+	}
+}
+
+func TestJapaneseSeed(t *testing.T) {
+	defer SetLanguage("english")
+
+	if err := SetLanguage("japanese"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Japanese BIP39 test vector with a passphrase that changes under NFKD
+	mnemonic := strings.Repeat("あいこくしん　", 11) + "あおぞら"
+	passphrase := "㍍ガバヴァぱばぐゞちぢ十人十色"
+	expected := "a262d6fb6122ecf45be09c50492b31f92e9beb7d9a845987a02cefda57a15f9c467a17872029a9e92299b5cbdf306e3a0ee620245cbd508959b6cb7ca637bd55"
+
+	if seed := hex.EncodeToString(newSeed(mnemonic, passphrase)); seed != expected {
+		t.Errorf("Expected seed %s, got %s", expected, seed)
+	}
+}
+
+func TestTranslateMnemonic(t *testing.T) {
+	defer SetLanguage("english")
+
+	entropy := bytes.Repeat([]byte{0x7f}, 16)
+	mnemonic, err := EntropyToMnemonic(entropy)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, name := range []string{"french", "japanese", "spanish"} {
+		translated, err := TranslateMnemonic(mnemonic, wordlists.English, languages[name])
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", name, err)
+		}
+
+		// The translation decodes to the same entropy in the target language
+		if err := SetLanguage(name); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		decoded, err := MnemonicToEntropy(translated)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", name, err)
+		}
+		if !bytes.Equal(decoded, entropy) {
+			t.Errorf("%s: expected entropy %x, got %x", name, entropy, decoded)
+		}
+		SetLanguage("english")
+
+		back, err := TranslateMnemonic(translated, languages[name], wordlists.English)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", name, err)
+		}
+		if back != mnemonic {
+			t.Errorf("%s: expected round trip to %q, got %q", name, mnemonic, back)
+		}
+	}
+
+	japanese, _ := TranslateMnemonic(mnemonic, wordlists.English, wordlists.Japanese)
+	if strings.Count(japanese, ideographicSpace) != 11 {
+		t.Errorf("Expected Japanese words separated by U+3000, got %q", japanese)
+	}
+}
+
+func TestTranslateMnemonic_Invalid(t *testing.T) {
+	tests := []string{
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", // bad checksum
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon brokenn", // unknown word
+		"abandon abandon about", // bad length
+	}
+
+	for _, mnemonic := range tests {
+		if _, err := TranslateMnemonic(mnemonic, wordlists.English, wordlists.French); err == nil {
+			t.Errorf("Expected an error for %q", mnemonic)
+		}
+	}
+
+	if _, err := TranslateMnemonic("abandon", []string{"abandon"}, wordlists.French); err == nil {
+		t.Errorf("Expected an error for a short wordlist")
+	}
+}
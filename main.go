@@ -2,38 +2,84 @@ package main
 
 import (
 	"bufio"
+	"compress/gzip"
+	"context"
+	"flag"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
+	"math"
+	"math/big"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
 	bip39 "github.com/tyler-smith/go-bip39"
+	"golang.org/x/text/unicode/norm"
 )
 
 var BIP39Words []string
 
-// Reads BIP39 words from a file and returns them as a slice of strings
+// Reads BIP39 words from a file and returns them as a slice of strings. The
+// path "-" reads from standard input instead, so a list can be piped in.
 func readBIP39FromFile(filePath string) ([]string, error) {
+	if filePath == "-" {
+		return ReadBIP39(os.Stdin)
+	}
+
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %v", err)
 	}
 	defer file.Close()
 
+	return ReadBIP39(file)
+}
+
+// ReadBIP39 reads BIP39 words, one per line, from r. Surrounding whitespace
+// is trimmed and empty lines are skipped, and a leading UTF-8 byte order
+// mark, as Windows editors write, is dropped. Gzip-compressed input,
+// recognized by its magic header rather than a file extension, is
+// decompressed transparently.
+func ReadBIP39(r io.Reader) ([]string, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("error reading wordlist: %v", err)
+		}
+		defer zr.Close()
+		r = zr
+	} else {
+		r = br
+	}
+
 	var words []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		word := strings.TrimSpace(scanner.Text())
+	scanner := bufio.NewScanner(r)
+	for line := 0; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if line == 0 {
+			// TrimSpace keeps the BOM, which would taint the first word
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		word := strings.TrimSpace(text)
 		if word != "" {
 			words = append(words, word)
 		}
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading file: %v", err)
+		return nil, fmt.Errorf("error reading wordlist: %v", err)
 	}
 
 	return words, nil
@@ -48,107 +94,653 @@ func indicesToMnemonic(indices []int) []string {
 	return phrase
 }
 
-// generates mnemonic phrases
-func mnemonicGenerator(startIndices []int) func() ([]string, bool) {
-	// if no starting point is given, start from the first unique combination (0, 1, 2, ..., 11)
+// GeneratorMode selects which part of the phrase space a generator walks
+type GeneratorMode int
+
+const (
+	// Combinations yields every set of wordCount distinct words exactly once,
+	// as strictly increasing word indices: C(n, wordCount) phrases for an
+	// n-word list. Phrases that repeat a word or whose words are not in
+	// wordlist order are never produced.
+	Combinations GeneratorMode = iota
+
+	// WithRepetition walks the full radix-n odometer over the phrase
+	// positions: all n^wordCount phrases, including repeated words in any
+	// order such as "abandon abandon ... about". This covers every BIP39
+	// phrase of that length.
+	WithRepetition
+)
+
+// generates mnemonic phrases of wordCount words, walking the phrase space
+// selected by mode
+func mnemonicGenerator(startIndices []int, wordCount int, mode GeneratorMode) (func() ([]string, bool), error) {
+	next, err := indexGenerator(startIndices, wordCount, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() ([]string, bool) {
+		indices, more := next()
+		if indices == nil {
+			return nil, false
+		}
+		return indicesToMnemonic(indices), more
+	}, nil
+}
+
+// GeneratorFromMnemonic returns a mnemonicGenerator that starts at phrase,
+// so a run can be resumed from the last phrase it printed instead of from
+// raw indices. The words must be in the loaded wordlist and, like every
+// phrase the generator yields, in strictly increasing wordlist order.
+func GeneratorFromMnemonic(phrase []string) (func() ([]string, bool), error) {
+	indices, err := phraseIndices(phrase)
+	if err != nil {
+		return nil, err
+	}
+	return mnemonicGenerator(indices, len(indices), Combinations)
+}
+
+// phraseIndices converts the words of a phrase back to their wordlist indices
+func phraseIndices(phrase []string) ([]int, error) {
+	if len(phrase) == 0 {
+		return nil, fmt.Errorf("empty phrase")
+	}
+
+	indices := make([]int, len(phrase))
+	for i, word := range phrase {
+		idx, ok := WordIndex(word)
+		if !ok {
+			return nil, fmt.Errorf("word %d (%q) is not in the wordlist", i+1, word)
+		}
+		indices[i] = idx
+	}
+	return indices, nil
+}
+
+// MnemonicCandidate is a single combination yielded by candidateGenerator
+type MnemonicCandidate struct {
+	Indices []int    // word indices into BIP39Words
+	Words   []string // the words the indices select
+	Valid   bool     // whether the phrase passes BIP39 checksum validation
+}
+
+// generates mnemonic candidates of wordCount words, iterating the same
+// combinations as mnemonicGenerator
+func candidateGenerator(startIndices []int, wordCount int, mode GeneratorMode) (func() (MnemonicCandidate, bool), error) {
+	next, err := indexGenerator(startIndices, wordCount, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() (MnemonicCandidate, bool) {
+		indices, more := next()
+		if indices == nil {
+			return MnemonicCandidate{}, false
+		}
+
+		words := indicesToMnemonic(indices)
+		return MnemonicCandidate{
+			Indices: indices,
+			Words:   words,
+			Valid:   bip39.IsMnemonicValid(strings.Join(words, " ")),
+		}, more
+	}, nil
+}
+
+// generates the word index combinations behind mnemonicGenerator
+func indexGenerator(startIndices []int, wordCount int, mode GeneratorMode) (func() ([]int, bool), error) {
+	if wordCount <= 0 {
+		return nil, fmt.Errorf("invalid word count: %d", wordCount)
+	}
+
+	advance := nextCombination
+	switch mode {
+	case Combinations:
+	case WithRepetition:
+		advance = nextOdometer
+	default:
+		return nil, fmt.Errorf("unknown generator mode: %d", mode)
+	}
+
+	// if no starting point is given, start from the first phrase of the mode:
+	// all zeros with repetition, else the first unique combination (0, 1, 2, ..., wordCount-1)
 	if startIndices == nil {
-		startIndices = make([]int, 12)
-		for i := range startIndices {
-			startIndices[i] = i // Initialize with unique indices: 0, 1, 2, ..., 11
+		startIndices = make([]int, wordCount)
+		if mode == Combinations {
+			for i := range startIndices {
+				startIndices[i] = i // Initialize with unique indices: 0, 1, 2, ..., wordCount-1
+			}
+		}
+	}
+
+	if len(startIndices) != wordCount {
+		return nil, fmt.Errorf("start indices length %d does not match word count %d", len(startIndices), wordCount)
+	}
+
+	listSize := len(BIP39Words)
+	for i, idx := range startIndices {
+		if idx < 0 || idx >= listSize {
+			return nil, fmt.Errorf("start index %d out of range: %d", i, idx)
+		}
+		if mode == Combinations && i > 0 && idx <= startIndices[i-1] {
+			return nil, fmt.Errorf("start indices must be strictly increasing: %v", startIndices)
 		}
 	}
 
 	current := append([]int(nil), startIndices...) // Copy of startIndices
-	wordCount := len(BIP39Words)
+	done := false
 
-	return func() ([]string, bool) {
-		// Yield the current combination as a mnemonic phrase
-		phrase := indicesToMnemonic(current)
-
-		// Increment the current indices (ensuring uniqueness)
-		for i := 11; i >= 0; i-- {
-			if current[i] < wordCount-1 {
-				// Only increment if it's less than the maximum word count
-				current[i]++
-				// Ensure all previous indices are set to a unique value less than current[i]
-				for j := i + 1; j < 12; j++ {
-					current[j] = current[j-1] + 1 // Ensure uniqueness by incrementing
-				}
-				break
-			} else {
-				current[i] = 0 // Reset current index and carry over to the next higher digit
+	return func() ([]int, bool) {
+		if done {
+			return nil, false
+		}
+
+		// Yield a copy of the current combination
+		indices := append([]int(nil), current...)
+
+		// Advance to the next combination; False indicates the generator is done
+		if !advance(current, listSize) {
+			done = true
+			return indices, false
+		}
+
+		return indices, true // True means more combinations to generate
+	}, nil
+}
+
+// nextCombination advances indices in place to the next strictly increasing
+// combination of word indices below listSize, like an odometer whose digits
+// must stay in ascending order. After a carry every lower position is reset
+// to the smallest ascending values. It returns false once indices holds the
+// last combination.
+func nextCombination(indices []int, listSize int) bool {
+	n := len(indices)
+	for i := n - 1; i >= 0; i-- {
+		// Position i can grow as long as the positions after it still fit
+		if indices[i] < listSize-n+i {
+			indices[i]++
+			for j := i + 1; j < n; j++ {
+				indices[j] = indices[j-1] + 1
 			}
+			return true
 		}
+	}
+	return false
+}
 
-		// If we've exhausted all combinations (i.e., all indices are unique and at their max)
-		if isZeroSlice(current) {
-			return phrase, false // False indicates the generator is done
+// nextOdometer advances indices in place like an odometer with listSize
+// values per digit, allowing repeated and descending indices. It returns
+// false once indices holds the last phrase (every index listSize-1).
+func nextOdometer(indices []int, listSize int) bool {
+	for i := len(indices) - 1; i >= 0; i-- {
+		if indices[i] < listSize-1 {
+			indices[i]++
+			return true
 		}
+		indices[i] = 0
+	}
+	return false
+}
+
+// CombinationCount returns how many phrases the generator emits in
+// Combinations mode for a wordlist of wordlistSize words and phrases of
+// phraseLen words. Indices are unique and strictly increasing, so this is
+// wordlistSize choose phraseLen.
+func CombinationCount(wordlistSize, phraseLen int) *big.Int {
+	if wordlistSize < 0 || phraseLen < 0 || phraseLen > wordlistSize {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Binomial(int64(wordlistSize), int64(phraseLen))
+}
 
-		return phrase, true // True means more combinations to generate
+// PhraseCount returns how many phrases a generator in the given mode emits
+// for a wordlist of wordlistSize words and phrases of phraseLen words
+func PhraseCount(wordlistSize, phraseLen int, mode GeneratorMode) *big.Int {
+	if mode != WithRepetition {
+		return CombinationCount(wordlistSize, phraseLen)
+	}
+	if wordlistSize < 0 || phraseLen < 0 {
+		return big.NewInt(0)
 	}
+	return new(big.Int).Exp(big.NewInt(int64(wordlistSize)), big.NewInt(int64(phraseLen)), nil)
 }
 
-// Helper function to check if all elements in the slice are zero
-func isZeroSlice(slice []int) bool {
-	for _, v := range slice {
-		if v != 0 {
-			return false
+// generates only checksum-valid mnemonic phrases of wordCount words. The first
+// wordCount-1 words are iterated like indexGenerator in the given mode starting
+// at startPrefix, and the final word is computed from the checksum instead of
+// trying all 2048 candidates. In Combinations mode word indices are strictly
+// increasing as with mnemonicGenerator; WithRepetition yields every valid
+// phrase of that length.
+func validMnemonicGenerator(startPrefix []int, wordCount int, mode GeneratorMode) (func() ([]string, bool), error) {
+	if !isValidWordCount(wordCount) {
+		return nil, fmt.Errorf("invalid word count: %d", wordCount)
+	}
+	if len(BIP39Words) != BIP39WordlistSize {
+		return nil, fmt.Errorf("checksum computation requires a %d-word wordlist, got %d", BIP39WordlistSize, len(BIP39Words))
+	}
+
+	nextPrefix, err := indexGenerator(startPrefix, wordCount-1, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	var prefix, lastWords []int
+	prefixMore := true
+
+	// advance returns the next valid phrase, or false once the space is exhausted
+	advance := func() ([]string, bool) {
+		for len(lastWords) == 0 {
+			if !prefixMore {
+				return nil, false
+			}
+			prefix, prefixMore = nextPrefix()
+			lastWords = validLastWordIndices(prefix)
+
+			// Keep the final index above the prefix to preserve uniqueness
+			for mode == Combinations && len(lastWords) > 0 && lastWords[0] <= prefix[len(prefix)-1] {
+				lastWords = lastWords[1:]
+			}
 		}
+
+		phrase := indicesToMnemonic(append(append([]int(nil), prefix...), lastWords[0]))
+		lastWords = lastWords[1:]
+		return phrase, true
 	}
-	return true
+
+	// Look one phrase ahead so the caller knows whether more follow
+	pending, more := advance()
+	return func() ([]string, bool) {
+		phrase := pending
+		pending, more = advance()
+		return phrase, more
+	}, nil
 }
 
-// GenerateBTCAddress generates a Bitcoin address from a 12-word BIP39 mnemonic.
-func GenerateBTCAddress(mnemonic string) (string, error) {
+// ValidMnemonicGenerator enumerates every checksum-valid 12-word mnemonic
+// with no wasted candidates, starting at the 11-word prefix start (all
+// zeros if nil). The 12th word is not fully determined by the first 11: the
+// last word carries 7 more entropy bits besides the 4 checksum bits, so each
+// prefix has exactly 128 valid completions, all of which are yielded before
+// the prefix advances like an odometer. That is 2048^11 * 128 phrases, the
+// whole valid 12-word space. It returns an error for an invalid start or a
+// loaded wordlist that is not 2048 words.
+func ValidMnemonicGenerator(start []int) (func() (string, bool), error) {
+	gen, err := validMnemonicGenerator(start, 12, WithRepetition)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() (string, bool) {
+		phrase, more := gen()
+		if phrase == nil {
+			return "", false
+		}
+		return FormatMnemonic(phrase), more
+	}, nil
+}
+
+// deriveKey validates the mnemonic, derives its BIP32 master key for the
+// given passphrase and network and walks the given child indices from it
+func deriveKey(mnemonic, passphrase string, path []uint32, params *chaincfg.Params) (*hdkeychain.ExtendedKey, error) {
 	// Validate the mnemonic
-	if !bip39.IsMnemonicValid(mnemonic) {
-		return "", fmt.Errorf("invalid mnemonic")
+	if !IsMnemonicValid(mnemonic) {
+		return nil, ErrInvalidMnemonic
 	}
 
-	// Generate seed from the mnemonic
-	seed := bip39.NewSeed(mnemonic, "")
+	// Generate seed from the mnemonic and passphrase
+	seed := newSeed(mnemonic, passphrase)
+	defer Zero(seed)
+	return deriveKeyFromSeed(seed, path, params)
+}
 
+// deriveKeyFromSeed derives the BIP32 master key of a seed for the given
+// network and walks the given child indices from it
+func deriveKeyFromSeed(seed []byte, path []uint32, params *chaincfg.Params) (*hdkeychain.ExtendedKey, error) {
 	// Derive the master key from the seed using BIP32
-	masterKey, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	key, err := hdkeychain.NewMaster(seed, params)
+	if err != nil {
+		return nil, ErrDerivation{Depth: 0, Err: err}
+	}
+
+	for i, child := range path {
+		parent := key
+		key, err = parent.Derive(child)
+		// Intermediate keys are not returned, so wipe them right away
+		parent.Zero()
+		if err != nil {
+			return nil, ErrDerivation{Depth: i + 1, Err: err}
+		}
+	}
+
+	return key, nil
+}
+
+// newSeed derives the BIP39 seed of a mnemonic and passphrase. Both are NFKD
+// normalized first as BIP39 requires, so differently composed Unicode input
+// and any word separator (including the Japanese ideographic space) yield the
+// same seed.
+func newSeed(mnemonic, passphrase string) []byte {
+	mnemonic = collapseWhitespace(mnemonic)
+	return bip39.NewSeed(norm.NFKD.String(mnemonic), norm.NFKD.String(passphrase))
+}
+
+// GenerateBTCAddress generates a Bitcoin address from a BIP39 mnemonic of any
+// valid length (12, 15, 18, 21 or 24 words). Failures can be told apart with
+// errors.Is against ErrInvalidMnemonic and ErrAddressEncode, or errors.As
+// with an ErrDerivation.
+func GenerateBTCAddress(mnemonic string) (string, error) {
+	return GenerateBTCAddressForNet(mnemonic, &chaincfg.MainNetParams)
+}
+
+// GenerateBTCAddressForNet generates the first BIP44 address of a BIP39
+// mnemonic for the given network, e.g. chaincfg.TestNet3Params. The coin type
+// level of the path is taken from the network parameters.
+func GenerateBTCAddressForNet(mnemonic string, params *chaincfg.Params) (string, error) {
+	return generateBIP44Address(mnemonic, "", params)
+}
+
+// GenerateBTCAddressWithPassphrase generates a Bitcoin address from a BIP39
+// mnemonic protected by a passphrase (the "25th word"). Every passphrase
+// yields an entirely different address tree; an empty passphrase produces the
+// same address as GenerateBTCAddress.
+func GenerateBTCAddressWithPassphrase(mnemonic, passphrase string) (string, error) {
+	return generateBIP44Address(mnemonic, passphrase, &chaincfg.MainNetParams)
+}
+
+// generateBIP44Address derives the first BIP44 P2PKH address for a mnemonic,
+// passphrase and network. Any coin whose addresses follow the Bitcoin P2PKH
+// encoding is supported by supplying its params, see coins.go.
+func generateBIP44Address(mnemonic, passphrase string, params *chaincfg.Params) (string, error) {
+	if !IsMnemonicValid(mnemonic) {
+		return "", ErrInvalidMnemonic
+	}
+
+	seed := newSeed(mnemonic, passphrase)
+	defer Zero(seed)
+
+	// Derive the address at m/44'/coin'/0'/0/0 for the first account
+	return AddressFromSeed(seed, []uint32{
+		hdkeychain.HardenedKeyStart + 44,                // BIP44 purpose
+		hdkeychain.HardenedKeyStart + params.HDCoinType, // Coin type: 0 for Bitcoin, 1 for testnets
+		hdkeychain.HardenedKeyStart,                     // Account: 0
+		0,                                               // Change: 0 for external chain
+		0,                                               // Address index: 0
+	}, params)
+}
+
+// AddressFromSeed derives the legacy P2PKH address at path from a BIP39 seed
+// for the given network. It skips the PBKDF2 stretching of the mnemonic, so
+// callers that already hold the 64-byte seed can derive many addresses
+// cheaply. BIP32 accepts seeds of 16 to 64 bytes.
+func AddressFromSeed(seed []byte, path []uint32, params *chaincfg.Params) (string, error) {
+	if len(seed) < hdkeychain.MinSeedBytes || len(seed) > hdkeychain.MaxSeedBytes {
+		return "", fmt.Errorf("invalid seed length: %d bytes, must be between %d and %d", len(seed), hdkeychain.MinSeedBytes, hdkeychain.MaxSeedBytes)
+	}
+
+	childKey, err := deriveKeyFromSeed(seed, path, params)
+	if err != nil {
+		return "", err
+	}
+	defer childKey.Zero()
+
+	address, err := p2pkhAddress(childKey, params)
 	if err != nil {
-		return "", fmt.Errorf("failed to create master key: %v", err)
+		return "", fmt.Errorf("%w: %v", ErrAddressEncode, err)
 	}
+	return address, nil
+}
+
+// GenerateBTCAddressAtPath generates a Bitcoin address from a BIP39 mnemonic
+// at an arbitrary derivation path. Hardened levels must already include
+// hdkeychain.HardenedKeyStart.
+func GenerateBTCAddressAtPath(mnemonic string, path []uint32) (string, error) {
+	if len(path) == 0 {
+		return "", fmt.Errorf("derivation path is empty")
+	}
+	if !IsMnemonicValid(mnemonic) {
+		return "", ErrInvalidMnemonic
+	}
+
+	seed := newSeed(mnemonic, "")
+	defer Zero(seed)
+	return AddressFromSeed(seed, path, &chaincfg.MainNetParams)
+}
+
+// GenerateBTCAddresses generates the first count receiving addresses
+// (m/44'/0'/0'/0/i) for a BIP39 mnemonic.
+func GenerateBTCAddresses(mnemonic string, count int) ([]string, error) {
+	return GenerateBTCAddressesCtx(context.Background(), mnemonic, count)
+}
+
+// GenerateBTCAddressesCtx is GenerateBTCAddresses bounded by ctx. The context
+// is checked between derivations; once it is done the addresses derived so
+// far are returned together with the context's error.
+func GenerateBTCAddressesCtx(ctx context.Context, mnemonic string, count int) ([]string, error) {
+	return receivingAddresses(ctx, mnemonic, count, &chaincfg.MainNetParams)
+}
+
+// receivingAddresses derives the first count BIP44 receiving addresses
+// (m/44'/coin'/0'/0/i) of a mnemonic for the given network, checking ctx
+// between derivations like GenerateBTCAddressesCtx
+func receivingAddresses(ctx context.Context, mnemonic string, count int, params *chaincfg.Params) ([]string, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("invalid address count: %d", count)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Derive the external chain key once and only derive the address index per iteration
+	chainKey, err := deriveKey(mnemonic, "", []uint32{
+		hdkeychain.HardenedKeyStart + 44,                // BIP44 purpose
+		hdkeychain.HardenedKeyStart + params.HDCoinType, // Coin type: 0 for Bitcoin, 1 for testnets
+		hdkeychain.HardenedKeyStart,                     // Account: 0
+		0,                                               // Change: 0 for external chain
+	}, params)
+	if err != nil {
+		return nil, err
+	}
+	defer chainKey.Zero()
+
+	addresses := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		if err := ctx.Err(); err != nil {
+			return addresses, err
+		}
+
+		childKey, err := chainKey.Derive(uint32(i))
+		if err != nil {
+			return addresses, fmt.Errorf("failed to derive child key: %v", err)
+		}
+
+		address, err := p2pkhAddress(childKey, params)
+		childKey.Zero()
+		if err != nil {
+			return addresses, err
+		}
+		addresses = append(addresses, address)
+	}
+
+	return addresses, nil
+}
 
-	// Derive the child key (m/44'/0'/0'/0/0 for the first account)
-	childKey, err := masterKey.Derive(hdkeychain.HardenedKeyStart + 44) // BIP44 purpose
+// GenerateBTCAddressNestedSegWit generates a nested SegWit (P2SH-P2WPKH,
+// 3...) address from a BIP39 mnemonic using the BIP49 path m/49'/0'/0'/0/0.
+func GenerateBTCAddressNestedSegWit(mnemonic string) (string, error) {
+	childKey, err := deriveKey(mnemonic, "", []uint32{
+		hdkeychain.HardenedKeyStart + 49, // BIP49 purpose
+		hdkeychain.HardenedKeyStart,      // Coin type: 0 for Bitcoin
+		hdkeychain.HardenedKeyStart,      // Account: 0
+		0,                                // Change: 0 for external chain
+		0,                                // Address index: 0
+	}, &chaincfg.MainNetParams)
 	if err != nil {
-		return "", fmt.Errorf("failed to derive child key: %v", err)
+		return "", err
 	}
+	defer childKey.Zero()
+
+	return p2shP2wpkhAddress(childKey, &chaincfg.MainNetParams)
+}
 
-	childKey, err = childKey.Derive(hdkeychain.HardenedKeyStart) // Coin type: 0 for Bitcoin
+// GenerateBTCAddressTaproot generates a Taproot (P2TR, bc1p...) address from
+// a BIP39 mnemonic using the BIP86 path m/86'/0'/0'/0/0. The derived key is
+// the internal key; it is tweaked per BIP341 with no script tree to obtain
+// the output key, matching Bitcoin Core's tr() descriptors.
+func GenerateBTCAddressTaproot(mnemonic string) (string, error) {
+	childKey, err := deriveKey(mnemonic, "", []uint32{
+		hdkeychain.HardenedKeyStart + 86, // BIP86 purpose
+		hdkeychain.HardenedKeyStart,      // Coin type: 0 for Bitcoin
+		hdkeychain.HardenedKeyStart,      // Account: 0
+		0,                                // Change: 0 for external chain
+		0,                                // Address index: 0
+	}, &chaincfg.MainNetParams)
 	if err != nil {
-		return "", fmt.Errorf("failed to derive child key: %v", err)
+		return "", err
 	}
+	defer childKey.Zero()
+
+	return p2trAddress(childKey, &chaincfg.MainNetParams)
+}
 
-	childKey, err = childKey.Derive(hdkeychain.HardenedKeyStart) // Account: 0
+// ScanAccounts derives the first addrPerAccount receiving addresses of each
+// of the first numAccounts BIP44 accounts of a mnemonic. The result is keyed
+// by account path, e.g. "m/44'/0'/2'".
+func ScanAccounts(mnemonic string, numAccounts, addrPerAccount int) (map[string][]string, error) {
+	if numAccounts <= 0 {
+		return nil, fmt.Errorf("invalid account count: %d", numAccounts)
+	}
+	if addrPerAccount <= 0 {
+		return nil, fmt.Errorf("invalid address count: %d", addrPerAccount)
+	}
+
+	// Derive the coin key once and branch off it for every account
+	coinKey, err := deriveKey(mnemonic, "", []uint32{
+		hdkeychain.HardenedKeyStart + 44, // BIP44 purpose
+		hdkeychain.HardenedKeyStart,      // Coin type: 0 for Bitcoin
+	}, &chaincfg.MainNetParams)
 	if err != nil {
-		return "", fmt.Errorf("failed to derive child key: %v", err)
+		return nil, err
 	}
+	defer coinKey.Zero()
+
+	accounts := make(map[string][]string, numAccounts)
+	for account := 0; account < numAccounts; account++ {
+		addresses, err := accountAddresses(coinKey, uint32(account), addrPerAccount)
+		if err != nil {
+			return nil, err
+		}
+
+		accounts[fmt.Sprintf("m/44'/0'/%d'", account)] = addresses
+	}
+
+	return accounts, nil
+}
 
-	childKey, err = childKey.Derive(0) // Change: 0 for external chain
+// accountAddresses derives the first count receiving addresses of account
+// from a BIP44 coin key, wiping the keys it derives on the way
+func accountAddresses(coinKey *hdkeychain.ExtendedKey, account uint32, count int) ([]string, error) {
+	accountKey, err := coinKey.Derive(hdkeychain.HardenedKeyStart + account)
 	if err != nil {
-		return "", fmt.Errorf("failed to derive child key: %v", err)
+		return nil, fmt.Errorf("failed to derive child key: %v", err)
 	}
+	defer accountKey.Zero()
 
-	childKey, err = childKey.Derive(0) // Address index: 0
+	chainKey, err := accountKey.Derive(0) // Change: 0 for external chain
 	if err != nil {
-		return "", fmt.Errorf("failed to derive child key: %v", err)
+		return nil, fmt.Errorf("failed to derive child key: %v", err)
 	}
+	defer chainKey.Zero()
 
+	addresses := make([]string, count)
+	for i := range addresses {
+		childKey, err := chainKey.Derive(uint32(i))
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive child key: %v", err)
+		}
+
+		addresses[i], err = p2pkhAddress(childKey, &chaincfg.MainNetParams)
+		childKey.Zero()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return addresses, nil
+}
+
+// p2pkhAddress encodes the public key of an extended key as a legacy P2PKH
+// address for the given network
+func p2pkhAddress(key *hdkeychain.ExtendedKey, params *chaincfg.Params) (string, error) {
 	// Get the public key from the child key
-	pubKey, err := childKey.ECPubKey()
+	pubKey, err := key.ECPubKey()
 	if err != nil {
 		return "", fmt.Errorf("failed to get public key: %v", err)
 	}
 
 	// Generate the Bitcoin address
-	address, err := btcutil.NewAddressPubKey(pubKey.SerializeCompressed(), &chaincfg.MainNetParams)
+	address, err := btcutil.NewAddressPubKey(pubKey.SerializeCompressed(), params)
+	if err != nil {
+		return "", fmt.Errorf("failed to create address: %v", err)
+	}
+
+	return address.EncodeAddress(), nil
+}
+
+// p2wpkhAddress encodes the public key of an extended key as a native SegWit
+// P2WPKH address for the given network
+func p2wpkhAddress(key *hdkeychain.ExtendedKey, params *chaincfg.Params) (string, error) {
+	pubKey, err := key.ECPubKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to get public key: %v", err)
+	}
+
+	// The witness program is the HASH160 of the compressed public key
+	witnessProg := btcutil.Hash160(pubKey.SerializeCompressed())
+	address, err := btcutil.NewAddressWitnessPubKeyHash(witnessProg, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to create address: %v", err)
+	}
+
+	return address.EncodeAddress(), nil
+}
+
+// p2shP2wpkhAddress encodes the public key of an extended key as a nested
+// SegWit P2SH-P2WPKH address for the given network
+func p2shP2wpkhAddress(key *hdkeychain.ExtendedKey, params *chaincfg.Params) (string, error) {
+	pubKey, err := key.ECPubKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to get public key: %v", err)
+	}
+
+	// The redeem script is the P2WPKH witness program: OP_0 <HASH160(pubkey)>
+	redeemScript, err := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_0).
+		AddData(btcutil.Hash160(pubKey.SerializeCompressed())).
+		Script()
+	if err != nil {
+		return "", fmt.Errorf("failed to build redeem script: %v", err)
+	}
+
+	address, err := btcutil.NewAddressScriptHash(redeemScript, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to create address: %v", err)
+	}
+
+	return address.EncodeAddress(), nil
+}
+
+// p2trAddress encodes the public key of an extended key as a BIP86 Taproot
+// address for the given network. The key is the internal key; it is tweaked
+// per BIP341 with no script tree to obtain the output key.
+func p2trAddress(key *hdkeychain.ExtendedKey, params *chaincfg.Params) (string, error) {
+	internalKey, err := key.ECPubKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to get public key: %v", err)
+	}
+
+	// Q = P + H_TapTweak(P)G, encoded as a 32-byte x-only key
+	outputKey := txscript.ComputeTaprootKeyNoScript(internalKey)
+	address, err := btcutil.NewAddressTaproot(schnorr.SerializePubKey(outputKey), params)
 	if err != nil {
 		return "", fmt.Errorf("failed to create address: %v", err)
 	}
@@ -156,37 +748,378 @@ func GenerateBTCAddress(mnemonic string) (string, error) {
 	return address.EncodeAddress(), nil
 }
 
+// GenerateBTCAddressBech32 generates a native SegWit (P2WPKH, bc1...) address
+// from a BIP39 mnemonic using the BIP84 path m/84'/0'/0'/0/0.
+func GenerateBTCAddressBech32(mnemonic string) (string, error) {
+	childKey, err := deriveKey(mnemonic, "", []uint32{
+		hdkeychain.HardenedKeyStart + 84, // BIP84 purpose
+		hdkeychain.HardenedKeyStart,      // Coin type: 0 for Bitcoin
+		hdkeychain.HardenedKeyStart,      // Account: 0
+		0,                                // Change: 0 for external chain
+		0,                                // Address index: 0
+	}, &chaincfg.MainNetParams)
+	if err != nil {
+		return "", err
+	}
+	defer childKey.Zero()
+
+	return p2wpkhAddress(childKey, &chaincfg.MainNetParams)
+}
+
+// parseIndices parses a comma-separated list of word indices such as "0,1,2"
+func parseIndices(s string) ([]int, error) {
+	fields := strings.Split(s, ",")
+	indices := make([]int, len(fields))
+	for i, field := range fields {
+		idx, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return nil, fmt.Errorf("invalid index %q: %v", field, err)
+		}
+		indices[i] = idx
+	}
+	return indices, nil
+}
+
+// formats indices in the form accepted by parseIndices
+func formatIndices(indices []int) string {
+	fields := make([]string, len(indices))
+	for i, idx := range indices {
+		fields[i] = strconv.Itoa(idx)
+	}
+	return strings.Join(fields, ",")
+}
+
+// reports where an interrupted or timed out run stopped so it can be resumed
+// with -start, or by rerunning with the same -checkpoint when it is set
+func reportInterrupted(logger *slog.Logger, indices []int, checkpoint string) {
+	logger.Warn("stopped before the end, resume with -start", "start", formatIndices(indices))
+	if checkpoint != "" {
+		logger.Info("saved checkpoint", "path", checkpoint)
+	}
+}
+
+// generateConfig holds the settings of a runGenerate run
+type generateConfig struct {
+	start              []int         // indices of the first phrase, nil for the first of mode
+	wordCount          int           // phrase length
+	mode               GeneratorMode // part of the phrase space to walk
+	count              int           // maximum number of phrases to generate
+	validOnly          bool          // write only the phrases that pass the checksum
+	maxDuration        time.Duration // time limit of the run, none if zero
+	checkpoint         string        // file to save the resume indices to, if set
+	checkpointInterval time.Duration // minimum time between checkpoint saves during the run
+}
+
+// runGenerate generates up to cfg.count phrases and writes them to out, or
+// only the valid ones with cfg.validOnly, stopping early when ctx is done or
+// after cfg.maxDuration (no limit if zero). It returns the indices of the
+// next phrase to generate, which resume the run as its start, and nil once
+// the phrase space is exhausted. With a checkpoint set, the resume indices
+// are saved at most every cfg.checkpointInterval while running and once more
+// when the run stops, so even a killed run loses at most one interval of work.
+func runGenerate(ctx context.Context, cfg generateConfig, out OutputWriter) ([]int, int, error) {
+	gen, err := candidateGenerator(cfg.start, cfg.wordCount, cfg.mode)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		current   MnemonicCandidate
+		exhausted bool
+		runErr    error
+		lastSave  = time.Now()
+		count     int
+	)
+
+	// next feeds RunFor, stopping it on cancellation, at the count or after an error
+	next := func() ([]string, bool) {
+		if runErr != nil || ctx.Err() != nil || count >= cfg.count {
+			return nil, false
+		}
+
+		candidate, more := gen()
+
+		// Every phrase before candidate has been written, so it is the resume point
+		if cfg.checkpoint != "" && time.Since(lastSave) >= cfg.checkpointInterval {
+			if runErr = SaveGeneratorState(candidate.Indices, cfg.checkpoint); runErr != nil {
+				return nil, false
+			}
+			lastSave = time.Now()
+		}
+
+		current, exhausted = candidate, !more
+		return candidate.Words, more
+	}
+
+	limit := cfg.maxDuration
+	if limit <= 0 {
+		limit = time.Duration(math.MaxInt64)
+	}
+	generated, _ := RunFor(limit, next, func(words []string) {
+		count++
+		if !current.Valid && cfg.validOnly {
+			return
+		}
+
+		// Invalid mnemonics have no address
+		address, _ := GenerateBTCAddress(strings.Join(words, " "))
+		if err := out.Write(OutputRecord{Index: count, Mnemonic: words, Address: address}); err != nil {
+			runErr = fmt.Errorf("failed to write output: %v", err)
+		}
+	})
+	if runErr != nil {
+		return current.Indices, generated, runErr
+	}
+	if exhausted {
+		return nil, generated, nil
+	}
+
+	// The run stopped early, so the generator still holds the next phrase
+	resume, _ := gen()
+	if cfg.checkpoint != "" {
+		if err := SaveGeneratorState(resume.Indices, cfg.checkpoint); err != nil {
+			return resume.Indices, generated, err
+		}
+	}
+	return resume.Indices, generated, nil
+}
+
+// newLogger returns a logger writing to stderr at the named level, or only
+// warnings and errors when quiet is set
+func newLogger(level string, quiet bool) (*slog.Logger, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q: %v", level, err)
+	}
+	if quiet && l < slog.LevelWarn {
+		l = slog.LevelWarn
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: l})), nil
+}
+
+// setFlags returns the names of the flags given on the command line
+func setFlags() map[string]bool {
+	set := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	return set
+}
+
+// fatal logs msg at error level and exits
+func fatal(logger *slog.Logger, msg string, args ...any) {
+	logger.Error(msg, args...)
+	os.Exit(1)
+}
+
 func main() {
-	var err error
-	BIP39Words, err = readBIP39FromFile("english.txt")
+	wordlistPath := flag.String("wordlist", "english.txt", "path to the BIP39 wordlist, optionally gzip-compressed, or - for stdin")
+	language := flag.String("language", "", "use the built-in wordlist of this language instead of -wordlist: "+strings.Join(Languages(), ", ")+"; portuguese is not built in, load its list with -wordlist")
+	count := flag.Int("count", 100, "number of mnemonics to generate")
+	wordsFlag := flag.Int("words", 12, "phrase length to generate and search: 12, 15, 18, 21 or 24 (defaults to the length of -start or -from)")
+	start := flag.String("start", "", "comma-separated word indices to start generating from, e.g. 0,1,2,3,4,5,6,7,8,9,10,100")
+	from := flag.String("from", "", "mnemonic phrase to start generating from, e.g. the last one printed by a previous run")
+	format := flag.String("format", "text", "output format: text or json")
+	separator := flag.String("separator", "", "word separator of the text format (default a single space, or U+3000 for Japanese)")
+	target := flag.String("target", "", "search for the mnemonic whose first address is this address, using the BIP44/49/84/86 path matching its type")
+	targetsPath := flag.String("targets", "", "search for mnemonics matching any address in this file (one per line)")
+	checkpoint := flag.String("checkpoint", "", "file to save the generator's resume indices to, resuming from it when it already exists (not supported with -target or -targets)")
+	checkpointInterval := flag.Duration("checkpoint-interval", time.Minute, "how often to save -checkpoint while generating")
+	clean := flag.Bool("clean", false, "remove duplicate words from the wordlist and sort it before use")
+	repeat := flag.Bool("repeat", false, "generate every phrase, allowing repeated words in any order, instead of only ascending combinations of distinct words")
+	logLevel := flag.String("log-level", "info", "log level: debug, info, warn or error")
+	maxDuration := flag.Duration("max-duration", 0, "stop after this long, e.g. 30m, reporting where to resume (0 means no limit)")
+	quiet := flag.Bool("quiet", false, "only log warnings and errors and only print valid mnemonics, silencing progress output and invalid candidates")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags]\n\nGenerates BIP39 mnemonic combinations or searches them for an address.\n\nFlags:\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// Logs go to stderr so stdout only carries results
+	logger, err := newLogger(*logLevel, *quiet)
 	if err != nil {
-		log.Fatal(err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
 	}
-	// Start generating from a specific point (e.g., all zeros)
-	gen := mnemonicGenerator(nil)
 
-	// Simulating a process that stops after generating 100 mnemonics
-	for i := 0; i < 100; i++ {
-		phrase, more := gen()
-		if !more {
-			fmt.Println("Reached the end of combinations.")
-			break
-		}
-		fmt.Printf("Mnemonic #%d: %v\n", i+1, phrase)
-	}
-
-	// // Example of restarting from a specific point (e.g., indices [0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 100])
-	// fmt.Println("\nResuming from specific point...")
-	// specificStart := []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 100}
-	// gen = mnemonicGenerator(specificStart)
-
-	// // Continue generating from the saved state
-	// for i := 0; i < 10; i++ { // Generate the next 10 phrases
-	// 	phrase, more := gen()
-	// 	if !more {
-	// 		fmt.Println("Reached the end of combinations.")
-	// 		break
-	// 	}
-	// 	fmt.Printf("Mnemonic (resumed) #%d: %v\n", i+1, phrase)
-	// }
+	explicit := setFlags()
+	if !isValidWordCount(*wordsFlag) {
+		fatal(logger, "invalid -words", "err", ErrInvalidWordCount{Count: *wordsFlag})
+	}
+	if *language != "" {
+		if explicit["wordlist"] {
+			fatal(logger, "-language and -wordlist cannot be combined")
+		}
+		if err := SetLanguage(*language); err != nil {
+			fatal(logger, "invalid -language", "err", err)
+		}
+	} else {
+		words, err := readBIP39FromFile(*wordlistPath)
+		if err != nil {
+			fatal(logger, "failed to read wordlist", "err", err)
+		}
+		if *clean {
+			var report WordlistReport
+			words, report = CleanWordlist(words)
+			logger.Info("cleaned wordlist", "duplicates_removed", report.DuplicatesRemoved, "reordered", report.Reordered)
+		}
+		// A file holding a built-in list keeps that language's rules and
+		// word separator
+		fileLanguage := DetectLanguage(words)
+		if err = ValidateWordlistForLanguage(words, fileLanguage); err != nil {
+			fatal(logger, "invalid wordlist", "path", *wordlistPath, "err", err)
+		}
+		if filepath.Base(*wordlistPath) == "english.txt" && !VerifyEnglishWordlist(words) {
+			logger.Warn("wordlist does not match the official BIP39 English list", "path", *wordlistPath, "sha256", WordlistSHA256(words))
+		}
+
+		// Validation and derivation must check phrases against the loaded list
+		UseWordlist(fileLanguage, words)
+	}
+
+	// Stop cleanly on Ctrl-C or SIGTERM instead of losing our place
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Generation enforces -max-duration through RunFor; searches run their
+	// workers under a context deadline instead
+	searchCtx := ctx
+	if *maxDuration > 0 {
+		var cancel context.CancelFunc
+		searchCtx, cancel = context.WithTimeout(ctx, *maxDuration)
+		defer cancel()
+	}
+
+	// Searches are sharded across workers and have no single resume point
+	if *checkpoint != "" && (*target != "" || *targetsPath != "") {
+		fatal(logger, "-checkpoint cannot be combined with -target or -targets")
+	}
+
+	opts := SearchOptions{Workers: runtime.NumCPU(), WordCount: *wordsFlag, Logger: logger}
+
+	if *targetsPath != "" {
+		file, err := os.Open(*targetsPath)
+		if err != nil {
+			fatal(logger, "failed to open targets", "err", err)
+		}
+		targets, err := LoadAddressSet(file)
+		file.Close()
+		if err != nil {
+			fatal(logger, "failed to read targets", "err", err)
+		}
+
+		matches, err := SearchForAddressesWithOptions(searchCtx, targets, opts)
+		if err != nil {
+			fatal(logger, "search failed", "err", err)
+		}
+		for address, mnemonic := range matches {
+			fmt.Printf("%s: %s\n", address, mnemonic)
+		}
+		if searchCtx.Err() == context.DeadlineExceeded {
+			fatal(logger, "search stopped after -max-duration", "duration", *maxDuration, "matches", len(matches))
+		}
+		if searchCtx.Err() != nil {
+			fatal(logger, "search interrupted, a rerun starts over", "matches", len(matches))
+		}
+		return
+	}
+
+	if *target != "" {
+		// A mistyped address would otherwise read as a search without a match
+		if _, err := AddressType(*target, &chaincfg.MainNetParams); err != nil {
+			fatal(logger, "invalid -target", "err", err)
+		}
+
+		mnemonic, ok := SearchForAddressContext(searchCtx, *target, opts)
+		if !ok && searchCtx.Err() == context.DeadlineExceeded {
+			fatal(logger, "search stopped after -max-duration", "duration", *maxDuration)
+		}
+		if !ok && searchCtx.Err() != nil {
+			fatal(logger, "search interrupted, a rerun starts over")
+		}
+		if !ok {
+			fatal(logger, "no mnemonic found", "address", *target)
+		}
+		fmt.Println(mnemonic)
+		return
+	}
+
+	var startIndices []int
+	if *start != "" {
+		startIndices, err = parseIndices(*start)
+		if err != nil {
+			fatal(logger, "invalid -start", "err", err)
+		}
+	}
+	if *from != "" {
+		startIndices, err = phraseIndices(strings.Fields(NormalizeMnemonic(*from)))
+		if err != nil {
+			fatal(logger, "invalid -from", "err", err)
+		}
+	}
+	if *checkpoint != "" {
+		if _, err := os.Stat(*checkpoint); err == nil {
+			startIndices, err = LoadGeneratorState(*checkpoint)
+			if err != nil {
+				fatal(logger, "invalid -checkpoint", "err", err)
+			}
+			logger.Info("resuming from checkpoint", "path", *checkpoint, "start", formatIndices(startIndices))
+		}
+	}
+
+	// A start point fixes the phrase length unless -words asks for another
+	wordCount := *wordsFlag
+	if startIndices != nil {
+		if explicit["words"] && len(startIndices) != wordCount {
+			fatal(logger, "start point does not match -words", "start_words", len(startIndices), "words", wordCount)
+		}
+		wordCount = len(startIndices)
+		if !isValidWordCount(wordCount) {
+			fatal(logger, "invalid start point", "err", ErrInvalidWordCount{Count: wordCount})
+		}
+	}
+
+	out, err := newOutputWriter(*format, *separator, os.Stdout)
+	if err != nil {
+		fatal(logger, "invalid -format", "err", err)
+	}
+
+	mode := Combinations
+	if *repeat {
+		mode = WithRepetition
+	}
+
+	logger.Info("generating mnemonics", "total_combinations", PhraseCount(len(BIP39Words), wordCount, mode))
+
+	// Start generating from the given point, or the first combination
+	next, generated, err := runGenerate(ctx, generateConfig{
+		start:              startIndices,
+		wordCount:          wordCount,
+		mode:               mode,
+		count:              *count,
+		validOnly:          *quiet,
+		checkpoint:         *checkpoint,
+		checkpointInterval: *checkpointInterval,
+		maxDuration:        *maxDuration,
+	}, out)
+	if err != nil {
+		fatal(logger, "generation failed", "err", err)
+	}
+
+	switch {
+	case next == nil:
+		logger.Info("reached the end of combinations", "generated", generated)
+		if *checkpoint != "" {
+			// Nothing is left to resume, so a rerun must not repeat the last phrases
+			if err := os.Remove(*checkpoint); err != nil && !os.IsNotExist(err) {
+				fatal(logger, "failed to remove checkpoint", "err", err)
+			}
+		}
+	case ctx.Err() != nil:
+		reportInterrupted(logger, next, *checkpoint)
+		os.Exit(130)
+	case generated < *count:
+		// Neither interrupted nor out of phrases, so RunFor ran out of time
+		logger.Info("reached -max-duration", "duration", *maxDuration, "generated", generated)
+		reportInterrupted(logger, next, *checkpoint)
+	}
 }
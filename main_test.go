@@ -1,10 +1,56 @@
 package main
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	bip39 "github.com/tyler-smith/go-bip39"
+	"github.com/tyler-smith/go-bip39/wordlists"
 )
 
+// useEnglishWordlist loads english.txt with UseWordlist, so the generator,
+// validation and the bip39 library all use it, and restores the previous
+// wordlist when the test ends
+func useEnglishWordlist(tb testing.TB) []string {
+	tb.Helper()
+
+	words, err := readBIP39FromFile("english.txt")
+	if err != nil {
+		tb.Fatalf("Error reading from file: %v", err)
+	}
+	restoreWordlist(tb)
+	UseWordlist("english", words)
+	return words
+}
+
+// useTestWords makes words, typically a handful of English words, the
+// generator's wordlist until the test ends. The bip39 library keeps its full
+// list, so phrases built from words still validate and derive.
+func useTestWords(tb testing.TB, words []string) {
+	restoreWordlist(tb)
+	BIP39Words = words
+}
+
+// restoreWordlist puts back the current wordlist settings when the test ends
+func restoreWordlist(tb testing.TB) {
+	words, language, libraryWords := BIP39Words, currentLanguage, bip39.GetWordList()
+	tb.Cleanup(func() {
+		BIP39Words, currentLanguage = words, language
+		bip39.SetWordList(libraryWords)
+	})
+}
+
 // Test the readBIP39FromFile function
 func TestReadBIP39FromTempFile(t *testing.T) {
 	// Create a temporary file with some BIP39 words for testing
@@ -50,6 +96,34 @@ absent
 	}
 }
 
+func TestReadBIP39FromStdin(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "bip39_stdin_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	if _, err := tmpFile.WriteString("abandon\nability\nable\n"); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+	if _, err := tmpFile.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("Failed to rewind temp file: %v", err)
+	}
+
+	stdin := os.Stdin
+	os.Stdin = tmpFile
+	defer func() { os.Stdin = stdin }()
+
+	words, err := readBIP39FromFile("-")
+	if err != nil {
+		t.Fatalf("Error reading from stdin: %v", err)
+	}
+	if strings.Join(words, " ") != "abandon ability able" {
+		t.Errorf("Expected abandon ability able, got %v", words)
+	}
+}
+
 func TestReadBIP39FromActualFile(t *testing.T) {
 	words, err := readBIP39FromFile("english.txt")
 	if err != nil {
@@ -90,3 +164,998 @@ func TestGenerateBTCAddress_InvalidMnemonic(t *testing.T) {
 		t.Fatalf("Expected an error for invalid mnemonic, got address %s", address)
 	}
 }
+
+func TestGenerateBTCAddress_Whitespace(t *testing.T) {
+	mnemonic := " mother  author\tsteel speak help absurd\nfeature flee photo distance broken long\n"
+
+	address, err := GenerateBTCAddress(mnemonic)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if address != "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD" {
+		t.Errorf("Expected address 19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD, got %s", address)
+	}
+}
+
+func TestGenerateBTCAddress_ErrorTypes(t *testing.T) {
+	_, err := GenerateBTCAddress("invalid mnemonic phrase")
+	if !errors.Is(err, ErrInvalidMnemonic) {
+		t.Errorf("Expected ErrInvalidMnemonic, got %v", err)
+	}
+
+	// hdkeychain rejects seeds shorter than 16 bytes when creating the master key
+	_, err = deriveKeyFromSeed(make([]byte, 8), []uint32{0}, &chaincfg.MainNetParams)
+	var derivErr ErrDerivation
+	if !errors.As(err, &derivErr) {
+		t.Fatalf("Expected ErrDerivation, got %v", err)
+	}
+	if derivErr.Depth != 0 {
+		t.Errorf("Expected depth 0, got %d", derivErr.Depth)
+	}
+	if !errors.Is(err, hdkeychain.ErrInvalidSeedLen) {
+		t.Errorf("Expected the hdkeychain error to be wrapped, got %v", err)
+	}
+
+	err = ErrDerivation{Depth: 3, Err: hdkeychain.ErrInvalidChild}
+	if got := err.Error(); !strings.Contains(got, "depth 3") {
+		t.Errorf("Expected the depth in %q", got)
+	}
+}
+
+func TestMnemonicGenerator24Words(t *testing.T) {
+	useEnglishWordlist(t)
+
+	start := make([]int, 24)
+	for i := range start {
+		start[i] = i
+	}
+
+	gen, err := mnemonicGenerator(start, 24, Combinations)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	phrase, more := gen()
+	if !more {
+		t.Fatalf("Expected more combinations after the first phrase")
+	}
+	if len(phrase) != 24 {
+		t.Fatalf("Expected 24 words, got %d", len(phrase))
+	}
+	for i, word := range phrase {
+		if word != BIP39Words[i] {
+			t.Errorf("Expected word %d to be %q, got %q", i, BIP39Words[i], word)
+		}
+	}
+
+	// The next phrase only advances the last position
+	phrase, _ = gen()
+	if phrase[23] != BIP39Words[24] {
+		t.Errorf("Expected last word %q, got %q", BIP39Words[24], phrase[23])
+	}
+}
+
+func TestMnemonicGenerator_InconsistentStart(t *testing.T) {
+	if _, err := mnemonicGenerator([]int{0, 1, 2}, 12, Combinations); err == nil {
+		t.Fatalf("Expected an error for a start slice that does not match the word count")
+	}
+}
+
+func TestGenerateBTCAddressBech32(t *testing.T) {
+	// BIP84 test vector
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	expectedAddress := "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"
+
+	address, err := GenerateBTCAddressBech32(mnemonic)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if address != expectedAddress {
+		t.Errorf("Expected address %s, got %s", expectedAddress, address)
+	}
+}
+
+func TestGenerateBTCAddressBech32_InvalidMnemonic(t *testing.T) {
+	address, err := GenerateBTCAddressBech32("invalid mnemonic phrase")
+	if err == nil {
+		t.Fatalf("Expected an error for invalid mnemonic, got address %s", address)
+	}
+}
+
+func TestGenerateBTCAddressAtPath(t *testing.T) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	// m/44'/0'/0'/0/0 must match GenerateBTCAddress
+	address, err := GenerateBTCAddressAtPath(mnemonic, []uint32{
+		hdkeychain.HardenedKeyStart + 44,
+		hdkeychain.HardenedKeyStart,
+		hdkeychain.HardenedKeyStart,
+		0,
+		0,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if address != "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD" {
+		t.Errorf("Expected address %s, got %s", "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD", address)
+	}
+
+	// A different address index yields a different address
+	other, err := GenerateBTCAddressAtPath(mnemonic, []uint32{
+		hdkeychain.HardenedKeyStart + 44,
+		hdkeychain.HardenedKeyStart,
+		hdkeychain.HardenedKeyStart,
+		0,
+		4,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if other == address {
+		t.Errorf("Expected a different address for index 4, got %s", other)
+	}
+}
+
+func TestGenerateBTCAddressAtPath_EmptyPath(t *testing.T) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	if _, err := GenerateBTCAddressAtPath(mnemonic, nil); err == nil {
+		t.Fatalf("Expected an error for an empty path")
+	}
+}
+
+func TestAddressFromSeed(t *testing.T) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+	path := []uint32{
+		hdkeychain.HardenedKeyStart + 44,
+		hdkeychain.HardenedKeyStart,
+		hdkeychain.HardenedKeyStart,
+		0,
+		0,
+	}
+
+	address, err := AddressFromSeed(bip39.NewSeed(mnemonic, ""), path, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if address != "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD" {
+		t.Errorf("Expected address 19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD, got %s", address)
+	}
+
+	for _, size := range []int{0, 15, 65} {
+		if _, err := AddressFromSeed(make([]byte, size), path, &chaincfg.MainNetParams); err == nil {
+			t.Errorf("Expected an error for a %d-byte seed", size)
+		}
+	}
+}
+
+func TestGenerateBTCAddresses(t *testing.T) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	addresses, err := GenerateBTCAddresses(mnemonic, 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(addresses) != 3 {
+		t.Fatalf("Expected 3 addresses, got %d", len(addresses))
+	}
+
+	for i, address := range addresses {
+		expected, err := GenerateBTCAddressAtPath(mnemonic, []uint32{
+			hdkeychain.HardenedKeyStart + 44,
+			hdkeychain.HardenedKeyStart,
+			hdkeychain.HardenedKeyStart,
+			0,
+			uint32(i),
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if address != expected {
+			t.Errorf("Expected address %d to be %s, got %s", i, expected, address)
+		}
+	}
+}
+
+func TestGenerateBTCAddresses_InvalidCount(t *testing.T) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	if _, err := GenerateBTCAddresses(mnemonic, 0); err == nil {
+		t.Fatalf("Expected an error for a zero count")
+	}
+}
+
+func TestGenerateBTCAddressesCtx(t *testing.T) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	addresses, err := GenerateBTCAddressesCtx(context.Background(), mnemonic, 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(addresses) != 3 || addresses[0] != "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD" {
+		t.Errorf("Expected 3 addresses starting with 19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD, got %v", addresses)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	addresses, err = GenerateBTCAddressesCtx(ctx, mnemonic, 3)
+	if err != context.Canceled {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if len(addresses) != 0 {
+		t.Errorf("Expected no addresses from a cancelled context, got %v", addresses)
+	}
+}
+
+func TestGenerateBTCAddressForNet(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	tests := []struct {
+		params   *chaincfg.Params
+		expected string
+	}{
+		{&chaincfg.MainNetParams, "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA"},
+		{&chaincfg.TestNet3Params, "mkpZhYtJu2r87Js3pDiWJDmPte2NRZ8bJV"},
+		{&chaincfg.RegressionNetParams, "mkpZhYtJu2r87Js3pDiWJDmPte2NRZ8bJV"},
+	}
+
+	for _, tt := range tests {
+		address, err := GenerateBTCAddressForNet(mnemonic, tt.params)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.params.Name, err)
+		}
+		if address != tt.expected {
+			t.Errorf("%s: expected address %s, got %s", tt.params.Name, tt.expected, address)
+		}
+	}
+}
+
+func TestGenerateBTCAddressWithPassphrase(t *testing.T) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	// An empty passphrase matches the passphrase-less address
+	address, err := GenerateBTCAddressWithPassphrase(mnemonic, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if address != "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD" {
+		t.Errorf("Expected address %s, got %s", "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD", address)
+	}
+
+	// Any other passphrase yields a different address
+	other, err := GenerateBTCAddressWithPassphrase(mnemonic, "TREZOR")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if other == address {
+		t.Errorf("Expected a different address for a non-empty passphrase, got %s", other)
+	}
+}
+
+func TestValidMnemonicGenerator(t *testing.T) {
+	words := useEnglishWordlist(t)
+
+	gen, err := validMnemonicGenerator(nil, 12, Combinations)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Brute force the first valid completion of the first prefix for comparison
+	var expected string
+	for last := 11; last < len(words); last++ {
+		candidate := strings.Join(append(indicesToMnemonic([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}), words[last]), " ")
+		if bip39.IsMnemonicValid(candidate) {
+			expected = candidate
+			break
+		}
+	}
+
+	for i := 0; i < 200; i++ {
+		phrase, more := gen()
+		if !more {
+			t.Fatalf("Generator exhausted after %d phrases", i)
+		}
+
+		mnemonic := strings.Join(phrase, " ")
+		if i == 0 && mnemonic != expected {
+			t.Errorf("Expected first phrase %q, got %q", expected, mnemonic)
+		}
+		if !bip39.IsMnemonicValid(mnemonic) {
+			t.Fatalf("Phrase %d is not a valid mnemonic: %q", i, mnemonic)
+		}
+	}
+}
+
+func TestValidMnemonicGenerator_InvalidWordCount(t *testing.T) {
+	if _, err := validMnemonicGenerator(nil, 13, Combinations); err == nil {
+		t.Fatalf("Expected an error for a 13-word phrase length")
+	}
+}
+
+func TestValidMnemonicGeneratorExported(t *testing.T) {
+	useEnglishWordlist(t)
+
+	gen, err := ValidMnemonicGenerator(nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The all-"abandon" prefix has 128 completions in wordlist order,
+	// starting with the BIP39 test vector
+	seen := make(map[string]bool)
+	for i := 0; i < 128; i++ {
+		mnemonic, more := gen()
+		if !more {
+			t.Fatalf("Generator exhausted after %d phrases", i)
+		}
+		if i == 0 && mnemonic != "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about" {
+			t.Errorf("Expected the first phrase to end in about, got %q", mnemonic)
+		}
+		if !bip39.IsMnemonicValid(mnemonic) {
+			t.Fatalf("Phrase %d is not a valid mnemonic: %q", i, mnemonic)
+		}
+		if !strings.HasPrefix(mnemonic, strings.Repeat("abandon ", 11)) {
+			t.Errorf("Expected phrase %d to keep the first prefix, got %q", i, mnemonic)
+		}
+		seen[mnemonic] = true
+	}
+	if len(seen) != 128 {
+		t.Errorf("Expected 128 distinct completions, got %d", len(seen))
+	}
+
+	// Then the prefix advances by one word
+	mnemonic, _ := gen()
+	if !strings.HasPrefix(mnemonic, strings.Repeat("abandon ", 10)+"ability ") {
+		t.Errorf("Expected the next prefix to end in ability, got %q", mnemonic)
+	}
+
+	start := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	gen, err = ValidMnemonicGenerator(start)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	mnemonic, _ = gen()
+	if !strings.HasPrefix(mnemonic, strings.Join(indicesToMnemonic(start), " ")+" ") {
+		t.Errorf("Expected the phrase to start at the given prefix, got %q", mnemonic)
+	}
+
+	if _, err := ValidMnemonicGenerator([]int{0, 1}); err == nil {
+		t.Errorf("Expected an error for an invalid start")
+	}
+}
+
+// recordWriter collects the records written to it
+type recordWriter struct {
+	records []OutputRecord
+}
+
+func (r *recordWriter) Write(record OutputRecord) error {
+	r.records = append(r.records, record)
+	return nil
+}
+
+func TestRunGenerate_Resume(t *testing.T) {
+	useEnglishWordlist(t)
+
+	// One uninterrupted run is the reference
+	var full recordWriter
+	if _, _, err := runGenerate(context.Background(), generateConfig{wordCount: 12, mode: Combinations, count: 6}, &full); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Stop after 3 phrases, then resume from the checkpoint
+	checkpoint := filepath.Join(t.TempDir(), "state.json")
+	cfg := generateConfig{wordCount: 12, mode: Combinations, count: 3, checkpoint: checkpoint}
+	var first recordWriter
+	next, generated, err := runGenerate(context.Background(), cfg, &first)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if generated != 3 {
+		t.Fatalf("Expected 3 phrases generated, got %d", generated)
+	}
+
+	cfg.start, err = LoadGeneratorState(checkpoint)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if formatIndices(cfg.start) != formatIndices(next) {
+		t.Errorf("Expected the checkpoint %v to match the resume indices %v", cfg.start, next)
+	}
+
+	var second recordWriter
+	if _, _, err := runGenerate(context.Background(), cfg, &second); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	resumed := append(first.records, second.records...)
+	if len(resumed) != len(full.records) {
+		t.Fatalf("Expected %d phrases across both runs, got %d", len(full.records), len(resumed))
+	}
+	for i := range full.records {
+		if strings.Join(resumed[i].Mnemonic, " ") != strings.Join(full.records[i].Mnemonic, " ") {
+			t.Errorf("Phrase %d: expected %v, got %v", i, full.records[i].Mnemonic, resumed[i].Mnemonic)
+		}
+	}
+
+	// A cancelled run writes nothing and checkpoints where it would have started
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var none recordWriter
+	next, _, err = runGenerate(ctx, cfg, &none)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(none.records) != 0 || formatIndices(next) != formatIndices(cfg.start) {
+		t.Errorf("Expected no output and resume at %v, got %d records and %v", cfg.start, len(none.records), next)
+	}
+}
+
+func TestRunGenerate_ValidOnly(t *testing.T) {
+	useEnglishWordlist(t)
+
+	// abandon x12, then ... ability, able and about: only the last is valid
+	cfg := generateConfig{start: make([]int, 12), wordCount: 12, mode: WithRepetition, count: 4, validOnly: true}
+	var out recordWriter
+	_, generated, err := runGenerate(context.Background(), cfg, &out)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if generated != 4 {
+		t.Errorf("Expected 4 phrases generated, got %d", generated)
+	}
+	if len(out.records) != 1 {
+		t.Fatalf("Expected only the valid phrase to be written, got %d records", len(out.records))
+	}
+	if record := out.records[0]; record.Index != 4 || record.Address != "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA" {
+		t.Errorf("Expected record 4 with address 1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA, got %+v", record)
+	}
+}
+
+// slowWriter takes longer per record than the time limits of the tests
+type slowWriter struct {
+	recordWriter
+}
+
+func (s *slowWriter) Write(record OutputRecord) error {
+	time.Sleep(20 * time.Millisecond)
+	return s.recordWriter.Write(record)
+}
+
+func TestRunGenerate_MaxDuration(t *testing.T) {
+	useTestWords(t, []string{"abandon", "ability", "able", "about", "above"})
+
+	// The first record outlasts the limit, so the run resumes at the second
+	var out slowWriter
+	cfg := generateConfig{wordCount: 3, mode: Combinations, count: 100, maxDuration: 10 * time.Millisecond}
+	next, generated, err := runGenerate(context.Background(), cfg, &out)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if generated != 1 || len(out.records) != 1 {
+		t.Errorf("Expected 1 phrase generated, got %d (%d written)", generated, len(out.records))
+	}
+	if formatIndices(next) != "0,1,3" {
+		t.Errorf("Expected to resume at 0,1,3, got %v", next)
+	}
+}
+
+func TestRunGenerate_Exhausted(t *testing.T) {
+	useTestWords(t, []string{"abandon", "ability", "able", "about", "above"})
+
+	var out recordWriter
+	next, generated, err := runGenerate(context.Background(), generateConfig{wordCount: 3, mode: Combinations, count: 100}, &out)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if next != nil || generated != 10 {
+		t.Errorf("Expected all 10 phrases and no resume point, got %d and %v", generated, next)
+	}
+}
+
+func TestNewSeed_NFKD(t *testing.T) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	// "café" with a precomposed é and with e followed by a combining acute accent
+	composed, err := GenerateBTCAddressWithPassphrase(mnemonic, "caf\u00e9")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	decomposed, err := GenerateBTCAddressWithPassphrase(mnemonic, "cafe\u0301")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if composed != decomposed {
+		t.Errorf("Expected composed and decomposed passphrases to match, got %s and %s", composed, decomposed)
+	}
+}
+
+func TestMnemonicGenerator_Exhaustive(t *testing.T) {
+	useTestWords(t, []string{"abandon", "ability", "able", "about", "above"})
+
+	gen, err := mnemonicGenerator(nil, 3, Combinations)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for {
+		phrase, more := gen()
+
+		// Every combination is strictly increasing in wordlist order
+		indices := make([]int, len(phrase))
+		for i, word := range phrase {
+			indices[i], _ = WordIndex(word)
+			if i > 0 && indices[i] <= indices[i-1] {
+				t.Fatalf("Expected strictly increasing indices, got %v", indices)
+			}
+		}
+
+		key := strings.Join(phrase, " ")
+		if seen[key] {
+			t.Fatalf("Combination %q yielded twice", key)
+		}
+		seen[key] = true
+
+		if !more {
+			break
+		}
+	}
+
+	// 5 choose 3
+	if len(seen) != 10 {
+		t.Errorf("Expected 10 combinations, got %d", len(seen))
+	}
+
+	if phrase, more := gen(); phrase != nil || more {
+		t.Errorf("Expected an exhausted generator to yield nothing, got %v", phrase)
+	}
+}
+
+func TestMnemonicGenerator_WithRepetition(t *testing.T) {
+	useTestWords(t, []string{"abandon", "ability", "able"})
+
+	gen, err := mnemonicGenerator(nil, 2, WithRepetition)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var phrases []string
+	for {
+		phrase, more := gen()
+		phrases = append(phrases, strings.Join(phrase, " "))
+		if !more {
+			break
+		}
+	}
+
+	// 3^2 phrases in odometer order, repeats included
+	expected := []string{
+		"abandon abandon", "abandon ability", "abandon able",
+		"ability abandon", "ability ability", "ability able",
+		"able abandon", "able ability", "able able",
+	}
+	if len(phrases) != len(expected) {
+		t.Fatalf("Expected %d phrases, got %d: %v", len(expected), len(phrases), phrases)
+	}
+	for i := range expected {
+		if phrases[i] != expected[i] {
+			t.Errorf("Phrase %d: expected %q, got %q", i, expected[i], phrases[i])
+		}
+	}
+
+	if count := PhraseCount(3, 2, WithRepetition); count.Int64() != 9 {
+		t.Errorf("Expected PhraseCount of 9, got %s", count)
+	}
+
+	// Descending and repeated starts are allowed, out of range ones are not
+	if _, err := mnemonicGenerator([]int{2, 2}, 2, WithRepetition); err != nil {
+		t.Errorf("Expected no error for a repeated start, got %v", err)
+	}
+	if _, err := mnemonicGenerator([]int{0, 3}, 2, WithRepetition); err == nil {
+		t.Errorf("Expected an error for an out of range start")
+	}
+}
+
+func TestMnemonicGenerator_WithRepetitionReachesTestVector(t *testing.T) {
+	useEnglishWordlist(t)
+
+	gen, err := mnemonicGenerator(nil, 12, WithRepetition)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// abandon x11 about is the 4th phrase: "about" is word 3
+	var phrase []string
+	for i := 0; i < 4; i++ {
+		phrase, _ = gen()
+	}
+	expected := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	if strings.Join(phrase, " ") != expected {
+		t.Errorf("Expected %q, got %q", expected, strings.Join(phrase, " "))
+	}
+}
+
+func TestMnemonicGenerator_InvalidStart(t *testing.T) {
+	useTestWords(t, []string{"abandon", "ability", "able", "about", "above"})
+
+	for _, start := range [][]int{{0, 0, 1}, {2, 1, 3}, {0, 1, 5}, {-1, 0, 1}} {
+		if _, err := mnemonicGenerator(start, 3, Combinations); err == nil {
+			t.Errorf("Expected an error for start indices %v", start)
+		}
+	}
+}
+
+func TestGeneratorFromMnemonic(t *testing.T) {
+	useTestWords(t, []string{"abandon", "ability", "able", "about", "above"})
+
+	gen, err := GeneratorFromMnemonic([]string{"abandon", "able", "above"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Resumes at the phrase itself, then continues in generator order
+	expected := []string{"abandon able above", "abandon about above", "ability able about"}
+	for _, e := range expected {
+		phrase, _ := gen()
+		if strings.Join(phrase, " ") != e {
+			t.Errorf("Expected %q, got %q", e, strings.Join(phrase, " "))
+		}
+	}
+
+	invalid := [][]string{
+		nil,
+		{"abandon", "able", "zoo"},     // unknown word
+		{"able", "abandon", "above"},   // not in generator order
+		{"abandon", "abandon", "able"}, // repeated word
+	}
+	for _, phrase := range invalid {
+		if _, err := GeneratorFromMnemonic(phrase); err == nil {
+			t.Errorf("Expected an error for %v", phrase)
+		}
+	}
+}
+
+func TestNextCombination(t *testing.T) {
+	indices := []int{0, 1, 2}
+	count := 1
+	for nextCombination(indices, 5) {
+		for i := 1; i < len(indices); i++ {
+			if indices[i] <= indices[i-1] {
+				t.Fatalf("Expected strictly increasing indices, got %v", indices)
+			}
+		}
+		count++
+	}
+
+	// 5 choose 3
+	if count != 10 {
+		t.Errorf("Expected 10 combinations, got %d", count)
+	}
+}
+
+func TestCandidateGenerator(t *testing.T) {
+	words := useEnglishWordlist(t)
+
+	gen, err := candidateGenerator(nil, 12, Combinations)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	validCount := 0
+	for i := 0; i < 100; i++ {
+		candidate, more := gen()
+		if !more {
+			t.Fatalf("Generator exhausted after %d candidates", i)
+		}
+
+		if len(candidate.Indices) != 12 || len(candidate.Words) != 12 {
+			t.Fatalf("Expected 12 indices and words, got %d and %d", len(candidate.Indices), len(candidate.Words))
+		}
+		for j, idx := range candidate.Indices {
+			if candidate.Words[j] != words[idx] {
+				t.Errorf("Expected word %d to be %q, got %q", j, words[idx], candidate.Words[j])
+			}
+		}
+
+		if candidate.Valid != bip39.IsMnemonicValid(strings.Join(candidate.Words, " ")) {
+			t.Errorf("Valid flag does not match IsMnemonicValid for %v", candidate.Words)
+		}
+		if candidate.Valid {
+			validCount++
+		}
+	}
+
+	// Roughly one in 16 combinations has a valid 4-bit checksum
+	if validCount == 0 {
+		t.Errorf("Expected some valid candidates among the first 100")
+	}
+}
+
+func TestCombinationCount(t *testing.T) {
+	tests := []struct {
+		size, length int
+		expected     string
+	}{
+		{5, 3, "10"},
+		{5, 5, "1"},
+		{5, 0, "1"},
+		{3, 5, "0"},
+		{2048, 12, "11005261717918037175659349191168"},
+	}
+
+	for _, tt := range tests {
+		if count := CombinationCount(tt.size, tt.length); count.String() != tt.expected {
+			t.Errorf("CombinationCount(%d, %d): expected %s, got %s", tt.size, tt.length, tt.expected, count)
+		}
+	}
+}
+
+func TestGenerateBTCAddressNestedSegWit(t *testing.T) {
+	// BIP49 test vector
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	expectedAddress := "37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf"
+
+	address, err := GenerateBTCAddressNestedSegWit(mnemonic)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if address != expectedAddress {
+		t.Errorf("Expected address %s, got %s", expectedAddress, address)
+	}
+}
+
+func TestGenerateBTCAddressNestedSegWit_InvalidMnemonic(t *testing.T) {
+	address, err := GenerateBTCAddressNestedSegWit("invalid mnemonic phrase")
+	if err == nil {
+		t.Fatalf("Expected an error for invalid mnemonic, got address %s", address)
+	}
+}
+
+func TestGenerateBTCAddressTaproot(t *testing.T) {
+	// BIP86 test vector
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	expectedAddress := "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"
+
+	address, err := GenerateBTCAddressTaproot(mnemonic)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if address != expectedAddress {
+		t.Errorf("Expected address %s, got %s", expectedAddress, address)
+	}
+}
+
+func TestGenerateBTCAddressTaproot_InvalidMnemonic(t *testing.T) {
+	address, err := GenerateBTCAddressTaproot("invalid mnemonic phrase")
+	if err == nil {
+		t.Fatalf("Expected an error for invalid mnemonic, got address %s", address)
+	}
+}
+
+func TestReadBIP39(t *testing.T) {
+	words, err := ReadBIP39(strings.NewReader("abandon\n  ability \r\n\n\table\nabout"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expectedWords := []string{"abandon", "ability", "able", "about"}
+	if len(words) != len(expectedWords) {
+		t.Fatalf("Expected %d words, got %d", len(expectedWords), len(words))
+	}
+	for i, word := range expectedWords {
+		if words[i] != word {
+			t.Errorf("Expected word %d to be %q, but got %q", i, word, words[i])
+		}
+	}
+}
+
+func TestReadBIP39_BOM(t *testing.T) {
+	words, err := ReadBIP39(strings.NewReader("\ufeffabandon\r\nability\r\nable\r\n"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(words) != 3 || words[0] != "abandon" {
+		t.Errorf("Expected the BOM to be stripped from the first word, got %q", words)
+	}
+
+	// Only a leading BOM is a byte order mark
+	words, err = ReadBIP39(strings.NewReader("abandon\n\ufeffability\n"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(words) != 2 || words[1] != "\ufeffability" {
+		t.Errorf("Expected a BOM after the first line to be kept, got %q", words)
+	}
+}
+
+func TestReadBIP39_Gzip(t *testing.T) {
+	raw, err := os.ReadFile("english.txt")
+	if err != nil {
+		t.Fatalf("Error reading from file: %v", err)
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(raw); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "english.txt.gz")
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	words, err := readBIP39FromFile(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !VerifyEnglishWordlist(words) {
+		t.Errorf("Expected the decompressed wordlist to match english.txt, got %d words", len(words))
+	}
+
+	// The gzip magic followed by garbage is reported rather than read as text
+	if _, err := ReadBIP39(bytes.NewReader([]byte{0x1f, 0x8b, 'x', 'y'})); err == nil {
+		t.Errorf("Expected an error for corrupt gzip input")
+	}
+}
+
+func TestScanAccounts(t *testing.T) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	accounts, err := ScanAccounts(mnemonic, 3, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(accounts) != 3 {
+		t.Fatalf("Expected 3 accounts, got %d", len(accounts))
+	}
+
+	// Account 0 matches the batch helper
+	first, err := GenerateBTCAddresses(mnemonic, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for i, address := range accounts["m/44'/0'/0'"] {
+		if address != first[i] {
+			t.Errorf("Expected address %d of account 0 to be %s, got %s", i, first[i], address)
+		}
+	}
+
+	// Other accounts match the path-based derivation
+	address, err := GenerateBTCAddressAtPath(mnemonic, []uint32{
+		hdkeychain.HardenedKeyStart + 44,
+		hdkeychain.HardenedKeyStart,
+		hdkeychain.HardenedKeyStart + 2,
+		0,
+		1,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := accounts["m/44'/0'/2'"]; len(got) != 2 || got[1] != address {
+		t.Errorf("Expected address 1 of account 2 to be %s, got %v", address, got)
+	}
+}
+
+func TestScanAccounts_InvalidCounts(t *testing.T) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	if _, err := ScanAccounts(mnemonic, 0, 1); err == nil {
+		t.Errorf("Expected an error for zero accounts")
+	}
+	if _, err := ScanAccounts(mnemonic, 1, 0); err == nil {
+		t.Errorf("Expected an error for zero addresses")
+	}
+}
+
+func TestParseIndices(t *testing.T) {
+	indices, err := parseIndices("0, 1,2,100")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []int{0, 1, 2, 100}
+	if len(indices) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, indices)
+	}
+	for i := range expected {
+		if indices[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, indices)
+		}
+	}
+
+	for _, s := range []string{"", "0,,1", "0,a"} {
+		if _, err := parseIndices(s); err == nil {
+			t.Errorf("Expected an error for %q", s)
+		}
+	}
+}
+
+func TestFormatIndices(t *testing.T) {
+	indices := []int{0, 1, 2, 2047}
+	s := formatIndices(indices)
+	if s != "0,1,2,2047" {
+		t.Errorf("Expected 0,1,2,2047, got %s", s)
+	}
+
+	parsed, err := parseIndices(s)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for i := range indices {
+		if parsed[i] != indices[i] {
+			t.Errorf("Expected %v, got %v", indices, parsed)
+		}
+	}
+}
+
+func BenchmarkGenerateBTCAddress(b *testing.B) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	for i := 0; i < b.N; i++ {
+		if _, err := GenerateBTCAddress(mnemonic); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNewSeed(b *testing.B) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	for i := 0; i < b.N; i++ {
+		newSeed(mnemonic, "")
+	}
+}
+
+func BenchmarkMnemonicGeneratorNext(b *testing.B) {
+	// The embedded English list keeps the benchmark free of external files
+	useTestWords(b, wordlists.English)
+
+	gen, err := mnemonicGenerator(nil, 12, Combinations)
+	if err != nil {
+		b.Fatalf("Expected no error, got %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, more := gen(); !more {
+			b.Fatal("unexpected end of combinations")
+		}
+	}
+}
+
+func TestNewLogger(t *testing.T) {
+	tests := []struct {
+		level    string
+		quiet    bool
+		expected slog.Level
+	}{
+		{"info", false, slog.LevelInfo},
+		{"debug", false, slog.LevelDebug},
+		{"info", true, slog.LevelWarn},
+		{"error", true, slog.LevelError},
+	}
+
+	for _, tt := range tests {
+		logger, err := newLogger(tt.level, tt.quiet)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.level, err)
+		}
+		if !logger.Enabled(context.Background(), tt.expected) || logger.Enabled(context.Background(), tt.expected-1) {
+			t.Errorf("%s (quiet %v): expected minimum level %v", tt.level, tt.quiet, tt.expected)
+		}
+	}
+
+	if _, err := newLogger("loud", false); err == nil {
+		t.Errorf("Expected an error for an unknown level")
+	}
+}
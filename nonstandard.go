@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// GenerateBTCAddressNoChecksum derives the first BIP44 address
+// (m/44'/0'/0'/0/0) of an arbitrary phrase without BIP39 validation: the
+// words need not be in the wordlist and the checksum is not checked. The seed
+// is computed exactly as for a valid mnemonic.
+//
+// This is NOT standard BIP39. It exists to recover funds from "brain wallet"
+// style phrases that some wallets accepted; phrases that were not generated
+// from random entropy are easy to guess and must not be used for new wallets.
+// Use GenerateBTCAddress for anything else.
+func GenerateBTCAddressNoChecksum(mnemonic string) (string, error) {
+	if strings.TrimSpace(mnemonic) == "" {
+		return "", fmt.Errorf("empty phrase")
+	}
+
+	seed := newSeed(mnemonic, "")
+	defer Zero(seed)
+
+	childKey, err := deriveKeyFromSeed(seed, []uint32{
+		hdkeychain.HardenedKeyStart + 44, // BIP44 purpose
+		hdkeychain.HardenedKeyStart,      // Coin type: 0 for Bitcoin
+		hdkeychain.HardenedKeyStart,      // Account: 0
+		0,                                // Change: 0 for external chain
+		0,                                // Address index: 0
+	}, &chaincfg.MainNetParams)
+	if err != nil {
+		return "", err
+	}
+	defer childKey.Zero()
+
+	return p2pkhAddress(childKey, &chaincfg.MainNetParams)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestGenerateBTCAddressNoChecksum(t *testing.T) {
+	// A valid mnemonic derives the same address as GenerateBTCAddress
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+	address, err := GenerateBTCAddressNoChecksum(mnemonic)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if address != "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD" {
+		t.Errorf("Expected address 19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD, got %s", address)
+	}
+
+	// A phrase with a bad checksum is rejected by GenerateBTCAddress only
+	invalid := "mother author steel speak help absurd feature flee photo distance broken broken"
+	if _, err := GenerateBTCAddress(invalid); err == nil {
+		t.Fatalf("Expected GenerateBTCAddress to reject %q", invalid)
+	}
+	address, err = GenerateBTCAddressNoChecksum(invalid)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if address == "" || address == "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD" {
+		t.Errorf("Expected a distinct address for %q, got %q", invalid, address)
+	}
+
+	if _, err := GenerateBTCAddressNoChecksum("  "); err == nil {
+		t.Errorf("Expected an error for an empty phrase")
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// OutputRecord is a generated mnemonic and the address derived from it.
+// Address is empty when the mnemonic is not a valid BIP39 phrase.
+type OutputRecord struct {
+	Index    int      `json:"index"`
+	Mnemonic []string `json:"mnemonic"`
+	Address  string   `json:"address"`
+}
+
+// OutputWriter writes generated mnemonics in some output format
+type OutputWriter interface {
+	Write(record OutputRecord) error
+}
+
+// TextWriter writes records as human-readable lines
+type TextWriter struct {
+	w   io.Writer
+	sep string
+}
+
+// NewTextWriter returns a TextWriter writing to w that separates words with
+// the separator of the selected language
+func NewTextWriter(w io.Writer) *TextWriter {
+	return NewTextWriterWithSeparator(w, "")
+}
+
+// NewTextWriterWithSeparator returns a TextWriter writing to w that joins the
+// words of each mnemonic with sep, see JoinMnemonic
+func NewTextWriterWithSeparator(w io.Writer, sep string) *TextWriter {
+	return &TextWriter{w: w, sep: sep}
+}
+
+// Write writes a single record as a line of text
+func (t *TextWriter) Write(record OutputRecord) error {
+	mnemonic := JoinMnemonic(record.Mnemonic, t.sep)
+
+	var err error
+	if record.Address == "" {
+		_, err = fmt.Fprintf(t.w, "Mnemonic #%d: [%s]\n", record.Index, mnemonic)
+	} else {
+		_, err = fmt.Fprintf(t.w, "Mnemonic #%d: [%s] (%s)\n", record.Index, mnemonic, record.Address)
+	}
+	return err
+}
+
+// JSONWriter writes records as newline-delimited JSON objects. Each record is
+// written as soon as it is received so long runs can be streamed.
+type JSONWriter struct {
+	enc *json.Encoder
+}
+
+// NewJSONWriter returns a JSONWriter writing to w
+func NewJSONWriter(w io.Writer) *JSONWriter {
+	return &JSONWriter{enc: json.NewEncoder(w)}
+}
+
+// Write writes a single record as one line of JSON
+func (j *JSONWriter) Write(record OutputRecord) error {
+	return j.enc.Encode(record)
+}
+
+// newOutputWriter returns the OutputWriter for the named format ("text" or
+// "json"). sep is the word separator of the text format.
+func newOutputWriter(format, sep string, w io.Writer) (OutputWriter, error) {
+	switch format {
+	case "text":
+		return NewTextWriterWithSeparator(w, sep), nil
+	case "json":
+		return NewJSONWriter(w), nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q, expected text or json", format)
+	}
+}
+
+// WriteAddressCSV derives the legacy P2PKH address of a mnemonic at each of
+// paths on the given network and writes them to w as CSV with the columns
+// index, path, address and error. A path that fails to derive gets an empty
+// address and the failure in its error column instead of aborting the file;
+// only an invalid mnemonic or a write failure returns an error.
+func WriteAddressCSV(w io.Writer, mnemonic string, paths [][]uint32, params *chaincfg.Params) error {
+	return WriteAddressCSVWithDeriver(w, mnemonic, paths, btcDeriver{kind: P2PKH, params: params})
+}
+
+// WriteAddressCSVWithDeriver is WriteAddressCSV for any coin and address
+// type, deriving the address at each path with d
+func WriteAddressCSVWithDeriver(w io.Writer, mnemonic string, paths [][]uint32, d Deriver) error {
+	if !IsMnemonicValid(mnemonic) {
+		return ErrInvalidMnemonic
+	}
+	seed := newSeed(mnemonic, "")
+	defer Zero(seed)
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"index", "path", "address", "error"}); err != nil {
+		return fmt.Errorf("failed to write CSV: %v", err)
+	}
+
+	for i, path := range paths {
+		row := []string{strconv.Itoa(i), PathToString(path), "", ""}
+		if address, err := d.Address(seed, path); err != nil {
+			row[3] = err.Error()
+		} else {
+			row[2] = address
+		}
+
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV: %v", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestTextWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewTextWriter(&buf)
+
+	if err := w.Write(OutputRecord{Index: 1, Mnemonic: []string{"abandon", "ability"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := w.Write(OutputRecord{Index: 2, Mnemonic: []string{"abandon", "able"}, Address: "1abc"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := "Mnemonic #1: [abandon ability]\nMnemonic #2: [abandon able] (1abc)\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestTextWriter_Separator(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewTextWriterWithSeparator(&buf, "-")
+
+	if err := w.Write(OutputRecord{Index: 1, Mnemonic: []string{"abandon", "ability"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if expected := "Mnemonic #1: [abandon-ability]\n"; buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestJSONWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewJSONWriter(&buf)
+
+	records := []OutputRecord{
+		{Index: 1, Mnemonic: []string{"abandon", "ability"}},
+		{Index: 2, Mnemonic: []string{"abandon", "able"}, Address: "1abc"},
+	}
+	for _, record := range records {
+		if err := w.Write(record); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(records) {
+		t.Fatalf("Expected %d lines, got %d", len(records), len(lines))
+	}
+	if lines[1] != `{"index":2,"mnemonic":["abandon","able"],"address":"1abc"}` {
+		t.Errorf("Unexpected JSON line %q", lines[1])
+	}
+
+	for i, line := range lines {
+		var decoded OutputRecord
+		if err := json.Unmarshal([]byte(line), &decoded); err != nil {
+			t.Fatalf("Line %d is not valid JSON: %v", i, err)
+		}
+		if decoded.Index != records[i].Index || decoded.Address != records[i].Address {
+			t.Errorf("Expected %+v, got %+v", records[i], decoded)
+		}
+	}
+}
+
+func TestNewOutputWriter(t *testing.T) {
+	for _, format := range []string{"text", "json"} {
+		if _, err := newOutputWriter(format, "", &bytes.Buffer{}); err != nil {
+			t.Errorf("%s: expected no error, got %v", format, err)
+		}
+	}
+
+	if _, err := newOutputWriter("xml", "", &bytes.Buffer{}); err == nil {
+		t.Errorf("Expected an error for an unknown format")
+	}
+}
+
+func TestWriteAddressCSV(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	h := uint32(hdkeychain.HardenedKeyStart)
+
+	// hdkeychain refuses to derive beyond depth 255
+	tooDeep := make([]uint32, 256)
+
+	var buf bytes.Buffer
+	paths := [][]uint32{{h + 44, h, h, 0, 0}, tooDeep, {h + 44, h + 1, h, 0, 0}}
+	if err := WriteAddressCSV(&buf, mnemonic, paths, &chaincfg.MainNetParams); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Expected valid CSV, got %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("Expected a header and 3 rows, got %v", rows)
+	}
+
+	if strings.Join(rows[0], ",") != "index,path,address,error" {
+		t.Errorf("Unexpected header %v", rows[0])
+	}
+	if strings.Join(rows[1], ",") != "0,m/44'/0'/0'/0/0,1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA," {
+		t.Errorf("Unexpected first row %v", rows[1])
+	}
+	if rows[2][0] != "1" || rows[2][2] != "" || rows[2][3] == "" {
+		t.Errorf("Expected the second row to record a derivation error, got %v", rows[2])
+	}
+	if rows[3][0] != "2" || rows[3][2] == "" || rows[3][3] != "" {
+		t.Errorf("Expected the file to continue after a failed row, got %v", rows[3])
+	}
+
+	if err := WriteAddressCSV(&buf, "invalid mnemonic phrase", paths, &chaincfg.MainNetParams); err == nil {
+		t.Errorf("Expected an error for invalid mnemonic")
+	}
+}
+
+func TestWriteAddressCSVWithDeriver(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	d, _ := GetDeriver("eth")
+
+	var buf bytes.Buffer
+	if err := WriteAddressCSVWithDeriver(&buf, mnemonic, [][]uint32{childPath(externalChain(44, 60), 0)}, d); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Expected valid CSV, got %v", err)
+	}
+	if len(rows) != 2 || strings.Join(rows[1], ",") != "0,m/44'/60'/0'/0/0,0x9858EfFD232B4033E47d90003D41EC34EcaEda94," {
+		t.Errorf("Unexpected rows %v", rows)
+	}
+}
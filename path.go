@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+)
+
+// HardenedKeyStart is the offset added to a child index to mark it hardened,
+// the same value as hdkeychain.HardenedKeyStart
+const HardenedKeyStart uint32 = hdkeychain.HardenedKeyStart
+
+// Hardened returns the hardened child number of index, e.g. Hardened(44) for
+// the 44' level of a BIP44 path. It panics if index is not below
+// HardenedKeyStart, since the sum would wrap around to a normal child number;
+// validate untrusted indices first, as ParseDerivationPath does.
+func Hardened(index uint32) uint32 {
+	if IsHardened(index) {
+		panic(fmt.Sprintf("index %d is too large to harden", index))
+	}
+	return index + HardenedKeyStart
+}
+
+// IsHardened reports whether child is a hardened child number
+func IsHardened(child uint32) bool {
+	return child >= HardenedKeyStart
+}
+
+// ParseDerivationPath parses a BIP32 path such as "m/44'/0'/0'/0/0" into a
+// slice of child indices. Hardened levels may be marked with an apostrophe or
+// an "h" and are converted with Hardened.
+func ParseDerivationPath(s string) ([]uint32, error) {
+	segments := strings.Split(strings.TrimSpace(s), "/")
+	if segments[0] != "m" && segments[0] != "M" {
+		return nil, fmt.Errorf("derivation path %q must start with \"m\"", s)
+	}
+
+	path := make([]uint32, 0, len(segments)-1)
+	for i, segment := range segments[1:] {
+		hardened := false
+		if strings.HasSuffix(segment, "'") || strings.HasSuffix(segment, "h") || strings.HasSuffix(segment, "H") {
+			hardened = true
+			segment = segment[:len(segment)-1]
+		}
+
+		// ParseUint would accept a leading "+", so only allow plain digits
+		if segment == "" || strings.TrimLeft(segment, "0123456789") != "" {
+			return nil, fmt.Errorf("invalid component %d in derivation path %q", i+1, s)
+		}
+
+		// Indices at or above 2^31 are hardened numbers already, and
+		// hardening them again would wrap around
+		index, err := strconv.ParseUint(segment, 10, 32)
+		if err != nil || IsHardened(uint32(index)) {
+			return nil, fmt.Errorf("index out of range in component %d of derivation path %q, must be below %d", i+1, s, HardenedKeyStart)
+		}
+
+		child := uint32(index)
+		if hardened {
+			child = Hardened(child)
+		}
+		path = append(path, child)
+	}
+
+	return path, nil
+}
+
+// PathToString formats a slice of child indices in the canonical BIP32
+// notation, e.g. "m/44'/0'/0'/0/0", marking hardened levels with an
+// apostrophe. It is the inverse of ParseDerivationPath.
+func PathToString(path []uint32) string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, child := range path {
+		b.WriteString("/")
+		if IsHardened(child) {
+			b.WriteString(strconv.FormatUint(uint64(child-HardenedKeyStart), 10))
+			b.WriteString("'")
+		} else {
+			b.WriteString(strconv.FormatUint(uint64(child), 10))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+)
+
+func TestParseDerivationPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected []uint32
+	}{
+		{"m", []uint32{}},
+		{"m/44'/0'/0'/0/0", []uint32{hdkeychain.HardenedKeyStart + 44, hdkeychain.HardenedKeyStart, hdkeychain.HardenedKeyStart, 0, 0}},
+		{"m/0'/0", []uint32{hdkeychain.HardenedKeyStart, 0}},
+		{"M/0h/0", []uint32{hdkeychain.HardenedKeyStart, 0}},
+		{"m/84H/0'/2147483647", []uint32{hdkeychain.HardenedKeyStart + 84, hdkeychain.HardenedKeyStart, 2147483647}},
+	}
+
+	for _, tt := range tests {
+		path, err := ParseDerivationPath(tt.path)
+		if err != nil {
+			t.Errorf("ParseDerivationPath(%q): expected no error, got %v", tt.path, err)
+			continue
+		}
+		if len(path) != len(tt.expected) {
+			t.Errorf("ParseDerivationPath(%q): expected %v, got %v", tt.path, tt.expected, path)
+			continue
+		}
+		for i := range path {
+			if path[i] != tt.expected[i] {
+				t.Errorf("ParseDerivationPath(%q): expected %v, got %v", tt.path, tt.expected, path)
+				break
+			}
+		}
+	}
+}
+
+func TestParseDerivationPath_Invalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"44'/0'/0'",
+		"x/0",
+		"m/",
+		"m//0",
+		"m/abc",
+		"m/+1",
+		"m/-1",
+		"m/1''",
+		"m/2147483648",
+		"m/2147483648'",
+		"m/99999999999",
+	}
+
+	for _, path := range invalid {
+		if _, err := ParseDerivationPath(path); err == nil {
+			t.Errorf("ParseDerivationPath(%q): expected an error", path)
+		}
+	}
+}
+
+func TestPathToString(t *testing.T) {
+	tests := []struct {
+		path     []uint32
+		expected string
+	}{
+		{nil, "m"},
+		{[]uint32{hdkeychain.HardenedKeyStart + 44, hdkeychain.HardenedKeyStart, hdkeychain.HardenedKeyStart, 0, 0}, "m/44'/0'/0'/0/0"},
+		{[]uint32{hdkeychain.HardenedKeyStart - 1, 0xffffffff}, "m/2147483647/2147483647'"},
+	}
+
+	for _, tt := range tests {
+		if s := PathToString(tt.path); s != tt.expected {
+			t.Errorf("Expected %s, got %s", tt.expected, s)
+		}
+
+		path, err := ParseDerivationPath(tt.expected)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.expected, err)
+		}
+		if PathToString(path) != tt.expected {
+			t.Errorf("%s: expected the path to round-trip, got %v", tt.expected, path)
+		}
+	}
+}
+
+func TestHardened(t *testing.T) {
+	if Hardened(44) != hdkeychain.HardenedKeyStart+44 {
+		t.Errorf("Expected Hardened(44) to be %d, got %d", hdkeychain.HardenedKeyStart+44, Hardened(44))
+	}
+
+	// An index that is already hardened would wrap around
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Expected Hardened(HardenedKeyStart) to panic")
+			}
+		}()
+		Hardened(HardenedKeyStart)
+	}()
+
+	tests := []struct {
+		child    uint32
+		expected bool
+	}{
+		{0, false},
+		{44, false},
+		{HardenedKeyStart - 1, false},
+		{Hardened(0), true},
+		{Hardened(44), true},
+		{^uint32(0), true},
+	}
+	for _, tt := range tests {
+		if got := IsHardened(tt.child); got != tt.expected {
+			t.Errorf("IsHardened(%d): expected %v, got %v", tt.child, tt.expected, got)
+		}
+	}
+}
+
+func FuzzParseDerivationPath(f *testing.F) {
+	for _, seed := range []string{"m", "m/44'/0'/0'/0/0", "M/84h/0H/0'", "m/2147483647", "m/2147483648", "m//0", "m/+1", "m/0'/'", "m/٣"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		path, err := ParseDerivationPath(s)
+		if err != nil {
+			if path != nil {
+				t.Errorf("Expected no path alongside error %v, got %v", err, path)
+			}
+			return
+		}
+
+		// Every separator starts exactly one level
+		if len(path) != strings.Count(strings.TrimSpace(s), "/") {
+			t.Errorf("%q: expected %d levels, got %v", s, strings.Count(strings.TrimSpace(s), "/"), path)
+		}
+
+		formatted := PathToString(path)
+		roundTrip, err := ParseDerivationPath(formatted)
+		if err != nil {
+			t.Fatalf("%q: formatted path %q does not parse: %v", s, formatted, err)
+		}
+		if PathToString(roundTrip) != formatted {
+			t.Errorf("%q: expected %q to round-trip, got %v", s, formatted, roundTrip)
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// RateMeter measures a processing rate over a sliding time window, e.g.
+// candidates per second of a search. It is safe for concurrent use; Tick
+// only takes a short lock, so it can be fed from hot loops.
+type RateMeter struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	total   uint64
+	samples []rateSample // oldest first
+}
+
+// rateSample is the running total of a RateMeter at a point in time
+type rateSample struct {
+	at    time.Time
+	total uint64
+}
+
+// rateMeterResolution is how many samples per window a RateMeter keeps;
+// ticks closer together than window/rateMeterResolution share a sample
+const rateMeterResolution = 20
+
+// NewRateMeter returns a RateMeter averaging over the last window
+func NewRateMeter(window time.Duration) *RateMeter {
+	return &RateMeter{window: window, now: time.Now}
+}
+
+// Tick records n more processed items
+func (m *RateMeter) Tick(n uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.total += n
+
+	// Coalesce with the newest sample while it is recent, keeping the one
+	// before it as the base of the measurement
+	if k := len(m.samples); k >= 2 && now.Sub(m.samples[k-2].at) < m.window/rateMeterResolution {
+		m.samples[k-1] = rateSample{at: now, total: m.total}
+	} else {
+		m.samples = append(m.samples, rateSample{at: now, total: m.total})
+	}
+	m.prune(now)
+}
+
+// prune drops the samples that fell out of the window, keeping at least one
+// to measure from
+func (m *RateMeter) prune(now time.Time) {
+	drop := 0
+	for drop < len(m.samples)-1 && now.Sub(m.samples[drop].at) > m.window {
+		drop++
+	}
+	m.samples = append(m.samples[:0], m.samples[drop:]...)
+}
+
+// Rate returns the items processed per second over the window, or 0 before
+// anything was measured
+func (m *RateMeter) Rate() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.prune(now)
+	if len(m.samples) == 0 {
+		return 0
+	}
+
+	first := m.samples[0]
+	elapsed := now.Sub(first.at).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(m.total-first.total) / elapsed
+}
+
+// ETA estimates how long the remaining items take at the current rate. It
+// returns 0 while the rate is unknown, and saturates at the largest
+// time.Duration for spaces such as 2048 choose 12 that would take longer.
+func (m *RateMeter) ETA(remaining *big.Int) time.Duration {
+	rate := m.Rate()
+	if rate <= 0 || remaining.Sign() <= 0 {
+		return 0
+	}
+
+	seconds, _ := new(big.Float).Quo(new(big.Float).SetInt(remaining), big.NewFloat(rate)).Float64()
+	if seconds >= float64(math.MaxInt64)/float64(time.Second) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// formatRate renders a rate per second with a metric suffix, e.g. "1.2M/s"
+func formatRate(rate float64) string {
+	for _, unit := range []struct {
+		scale  float64
+		suffix string
+	}{{1e9, "G"}, {1e6, "M"}, {1e3, "k"}} {
+		if rate >= unit.scale {
+			return fmt.Sprintf("%.1f%s/s", rate/unit.scale, unit.suffix)
+		}
+	}
+	return fmt.Sprintf("%.1f/s", rate)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced time source for RateMeter tests
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func TestRateMeter(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	meter := NewRateMeter(10 * time.Second)
+	meter.now = clock.now
+
+	if rate := meter.Rate(); rate != 0 {
+		t.Errorf("Expected no rate before any ticks, got %f", rate)
+	}
+	if eta := meter.ETA(big.NewInt(100)); eta != 0 {
+		t.Errorf("Expected no ETA before any ticks, got %v", eta)
+	}
+
+	// 1000 items per second for 5 seconds
+	meter.Tick(0)
+	for i := 0; i < 50; i++ {
+		clock.advance(100 * time.Millisecond)
+		meter.Tick(100)
+	}
+	if rate := meter.Rate(); math.Abs(rate-1000) > 1 {
+		t.Errorf("Expected a rate of 1000/s, got %f", rate)
+	}
+	if eta := meter.ETA(big.NewInt(60000)); eta.Round(time.Second) != time.Minute {
+		t.Errorf("Expected an ETA of 1m, got %v", eta)
+	}
+
+	// Then 5000 per second; after a full window only the new rate counts
+	for i := 0; i < 200; i++ {
+		clock.advance(100 * time.Millisecond)
+		meter.Tick(500)
+	}
+	if rate := meter.Rate(); math.Abs(rate-5000) > 300 {
+		t.Errorf("Expected a rate of about 5000/s, got %f", rate)
+	}
+	if n := len(meter.samples); n > 2*rateMeterResolution+2 {
+		t.Errorf("Expected the samples to stay bounded, got %d", n)
+	}
+
+	// A huge space saturates instead of overflowing
+	if eta := meter.ETA(CombinationCount(2048, 12)); eta != time.Duration(math.MaxInt64) {
+		t.Errorf("Expected a saturated ETA, got %v", eta)
+	}
+}
+
+func TestFormatRate(t *testing.T) {
+	tests := map[float64]string{
+		0:       "0.0/s",
+		950:     "950.0/s",
+		1200:    "1.2k/s",
+		1234567: "1.2M/s",
+		3.5e9:   "3.5G/s",
+	}
+
+	for rate, expected := range tests {
+		if s := formatRate(rate); s != expected {
+			t.Errorf("formatRate(%v): expected %s, got %s", rate, expected, s)
+		}
+	}
+}
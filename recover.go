@@ -0,0 +1,88 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// RepairSwappedWords looks for a backup in which two adjacent words were
+// written in the wrong order. It swaps each adjacent pair of phrase in turn
+// and returns the first variant that is a valid mnemonic deriving
+// expectedAddress at path on the given network, encoded as the address's
+// AddressType as in VerifyMnemonicAddress. phrase itself is not modified;
+// false is returned when no single swap repairs it or expectedAddress does
+// not decode.
+func RepairSwappedWords(phrase []string, expectedAddress string, path []uint32, params *chaincfg.Params) ([]string, bool) {
+	if _, err := AddressType(expectedAddress, params); err != nil {
+		return nil, false
+	}
+
+	candidate := make([]string, len(phrase))
+	for i := 0; i+1 < len(phrase); i++ {
+		if phrase[i] == phrase[i+1] {
+			continue
+		}
+
+		copy(candidate, phrase)
+		candidate[i], candidate[i+1] = candidate[i+1], candidate[i]
+
+		mnemonic := strings.Join(candidate, " ")
+		// Most swaps break the checksum, which is far cheaper to check than
+		// deriving the address
+		if !IsMnemonicValid(mnemonic) {
+			continue
+		}
+		if ok, err := VerifyMnemonicAddress(mnemonic, expectedAddress, path, params); err == nil && ok {
+			return candidate, true
+		}
+	}
+
+	return nil, false
+}
+
+// RecoverMissingWord completes a phrase in which the word at unknownIndex is
+// lost. Every word of the loaded wordlist is tried at that position; the
+// checksum rules out all but a handful of them (about 1 in 16 for 12 words)
+// before any address is derived, and the first candidate deriving
+// expectedAddress at path on the given network, encoded as the address's
+// AddressType as in VerifyMnemonicAddress, is returned. Whatever placeholder
+// phrase holds at unknownIndex is ignored.
+func RecoverMissingWord(phrase []string, unknownIndex int, expectedAddress string, path []uint32, params *chaincfg.Params) ([]string, bool) {
+	if !isValidWordCount(len(phrase)) || unknownIndex < 0 || unknownIndex >= len(phrase) {
+		return nil, false
+	}
+	if _, err := AddressType(expectedAddress, params); err != nil {
+		return nil, false
+	}
+	if len(BIP39Words) != BIP39WordlistSize {
+		return nil, false
+	}
+
+	indices := make([]int, len(phrase))
+	for i, word := range phrase {
+		if i == unknownIndex {
+			continue
+		}
+		idx, ok := WordIndex(word)
+		if !ok {
+			return nil, false
+		}
+		indices[i] = idx
+	}
+
+	candidate := append([]string(nil), phrase...)
+	for idx, word := range BIP39Words {
+		indices[unknownIndex] = idx
+		if !hasValidChecksum(indices) {
+			continue
+		}
+
+		candidate[unknownIndex] = word
+		if ok, err := VerifyMnemonicAddress(strings.Join(candidate, " "), expectedAddress, path, params); err == nil && ok {
+			return candidate, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+var recoveryPath = []uint32{
+	hdkeychain.HardenedKeyStart + 44,
+	hdkeychain.HardenedKeyStart,
+	hdkeychain.HardenedKeyStart,
+	0,
+	0,
+}
+
+func TestRepairSwappedWords(t *testing.T) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+	address := "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD"
+
+	words := strings.Fields(mnemonic)
+	for i := 0; i+1 < len(words); i++ {
+		swapped := append([]string(nil), words...)
+		swapped[i], swapped[i+1] = swapped[i+1], swapped[i]
+		original := strings.Join(swapped, " ")
+
+		repaired, ok := RepairSwappedWords(swapped, address, recoveryPath, &chaincfg.MainNetParams)
+		if !ok {
+			t.Fatalf("swap %d: expected the phrase to be repaired", i)
+		}
+		if strings.Join(repaired, " ") != mnemonic {
+			t.Errorf("swap %d: expected %q, got %q", i, mnemonic, strings.Join(repaired, " "))
+		}
+		if strings.Join(swapped, " ") != original {
+			t.Errorf("swap %d: expected the input phrase to be left unchanged", i)
+		}
+	}
+}
+
+func TestRepairSwappedWords_SegWit(t *testing.T) {
+	words := strings.Fields("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about abandon")
+	path := []uint32{Hardened(84), Hardened(0), Hardened(0), 0, 0}
+
+	repaired, ok := RepairSwappedWords(words, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu", path, &chaincfg.MainNetParams)
+	if !ok {
+		t.Fatalf("Expected the phrase to be repaired against a BIP84 address")
+	}
+	if expected := strings.Repeat("abandon ", 11) + "about"; strings.Join(repaired, " ") != expected {
+		t.Errorf("Expected %q, got %q", expected, strings.Join(repaired, " "))
+	}
+}
+
+func TestRepairSwappedWords_NotFound(t *testing.T) {
+	words := strings.Fields("mother author steel speak help absurd feature flee photo distance broken long")
+
+	// The right words in the right order derive a different address, so no
+	// swap can match it
+	if repaired, ok := RepairSwappedWords(words, "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA", recoveryPath, &chaincfg.MainNetParams); ok {
+		t.Errorf("Expected no repair, got %v", repaired)
+	}
+	if _, ok := RepairSwappedWords(nil, "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD", recoveryPath, &chaincfg.MainNetParams); ok {
+		t.Errorf("Expected no repair for an empty phrase")
+	}
+}
+
+func TestRecoverMissingWord(t *testing.T) {
+	useEnglishWordlist(t)
+
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+	address := "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD"
+
+	for _, unknown := range []int{0, 5, 11} {
+		phrase := strings.Fields(mnemonic)
+		phrase[unknown] = "?"
+
+		recovered, ok := RecoverMissingWord(phrase, unknown, address, recoveryPath, &chaincfg.MainNetParams)
+		if !ok {
+			t.Fatalf("word %d: expected the phrase to be recovered", unknown)
+		}
+		if strings.Join(recovered, " ") != mnemonic {
+			t.Errorf("word %d: expected %q, got %q", unknown, mnemonic, strings.Join(recovered, " "))
+		}
+		if phrase[unknown] != "?" {
+			t.Errorf("word %d: expected the input phrase to be left unchanged", unknown)
+		}
+	}
+}
+
+func TestRecoverMissingWord_SegWit(t *testing.T) {
+	useEnglishWordlist(t)
+
+	phrase := strings.Fields(strings.Repeat("abandon ", 11) + "?")
+	tests := []struct {
+		purpose uint32
+		address string
+	}{
+		{49, "37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf"},
+		{84, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"},
+		{86, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"},
+	}
+
+	for _, tt := range tests {
+		path := []uint32{Hardened(tt.purpose), Hardened(0), Hardened(0), 0, 0}
+		recovered, ok := RecoverMissingWord(phrase, 11, tt.address, path, &chaincfg.MainNetParams)
+		if !ok {
+			t.Fatalf("Purpose %d: expected the phrase to be recovered", tt.purpose)
+		}
+		if recovered[11] != "about" {
+			t.Errorf("Purpose %d: expected the missing word about, got %q", tt.purpose, recovered[11])
+		}
+	}
+}
+
+func TestRecoverMissingWord_NotFound(t *testing.T) {
+	useEnglishWordlist(t)
+
+	phrase := strings.Fields("mother author steel speak help absurd feature flee photo distance broken long")
+	address := "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD"
+
+	if _, ok := RecoverMissingWord(phrase, 3, "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA", recoveryPath, &chaincfg.MainNetParams); ok {
+		t.Errorf("Expected no recovery for an address of another wallet")
+	}
+	if _, ok := RecoverMissingWord(phrase, 12, address, recoveryPath, &chaincfg.MainNetParams); ok {
+		t.Errorf("Expected no recovery for an out of range index")
+	}
+	unknownWord := append([]string(nil), phrase...)
+	unknownWord[1] = "notaword"
+	if _, ok := RecoverMissingWord(unknownWord, 3, address, recoveryPath, &chaincfg.MainNetParams); ok {
+		t.Errorf("Expected no recovery when another word is unknown")
+	}
+}
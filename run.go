@@ -0,0 +1,57 @@
+package main
+
+import (
+	"time"
+)
+
+// RunFor drives a phrase generator such as mnemonicGenerator, calling fn with
+// each phrase until d has elapsed or the generator is exhausted. It returns
+// how many phrases were processed and the word indices of the last one; the
+// indices are nil if nothing was processed. Generator start indices are
+// inclusive, so a later run must resume from the phrase after them, not from
+// the indices themselves.
+func RunFor(d time.Duration, gen func() ([]string, bool), fn func([]string)) (int, []int) {
+	deadline := time.Now().Add(d)
+
+	processed := 0
+	var last []string
+	for time.Now().Before(deadline) {
+		phrase, more := gen()
+		if phrase == nil {
+			break
+		}
+
+		fn(phrase)
+		processed++
+		last = phrase
+
+		if !more {
+			break
+		}
+	}
+
+	if last == nil {
+		return processed, nil
+	}
+
+	indices, _ := phraseIndices(last)
+	return processed, indices
+}
+
+// Take pulls up to n phrases from a phrase generator such as
+// mnemonicGenerator, stopping early if it is exhausted
+func Take(gen func() ([]string, bool), n int) [][]string {
+	var phrases [][]string
+	for len(phrases) < n {
+		phrase, more := gen()
+		if phrase == nil {
+			break
+		}
+		phrases = append(phrases, phrase)
+
+		if !more {
+			break
+		}
+	}
+	return phrases
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunFor_Exhausted(t *testing.T) {
+	useTestWords(t, []string{"abandon", "ability", "able", "about", "above"})
+
+	gen, err := mnemonicGenerator(nil, 3, Combinations)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	calls := 0
+	processed, last := RunFor(time.Minute, gen, func([]string) { calls++ })
+
+	// 5 choose 3, ending on the last combination
+	if processed != 10 || calls != 10 {
+		t.Errorf("Expected 10 phrases processed, got %d (%d calls)", processed, calls)
+	}
+	if len(last) != 3 || last[0] != 2 || last[1] != 3 || last[2] != 4 {
+		t.Errorf("Expected final indices [2 3 4], got %v", last)
+	}
+}
+
+func TestRunFor_Duration(t *testing.T) {
+	useTestWords(t, []string{"abandon", "ability", "able", "about", "above"})
+
+	gen, err := mnemonicGenerator(nil, 3, Combinations)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Each phrase takes longer than the whole budget, so only one runs
+	processed, last := RunFor(10*time.Millisecond, gen, func([]string) { time.Sleep(20 * time.Millisecond) })
+	if processed != 1 {
+		t.Errorf("Expected 1 phrase processed, got %d", processed)
+	}
+	if len(last) != 3 || last[0] != 0 || last[1] != 1 || last[2] != 2 {
+		t.Errorf("Expected final indices [0 1 2], got %v", last)
+	}
+
+	processed, last = RunFor(0, gen, func([]string) {})
+	if processed != 0 || last != nil {
+		t.Errorf("Expected nothing processed with no time, got %d and %v", processed, last)
+	}
+}
+
+func TestTake(t *testing.T) {
+	useTestWords(t, []string{"abandon", "ability", "able", "about", "above"})
+
+	gen, err := mnemonicGenerator(nil, 3, Combinations)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	phrases := Take(gen, 4)
+	if len(phrases) != 4 {
+		t.Fatalf("Expected 4 phrases, got %d", len(phrases))
+	}
+	if strings.Join(phrases[3], " ") != "abandon able about" {
+		t.Errorf("Expected the 4th phrase to be abandon able about, got %v", phrases[3])
+	}
+
+	// Only 6 of the 10 combinations remain
+	if phrases := Take(gen, 100); len(phrases) != 6 {
+		t.Errorf("Expected the remaining 6 phrases, got %d", len(phrases))
+	}
+	if phrases := Take(gen, 100); len(phrases) != 0 {
+		t.Errorf("Expected an exhausted generator to yield nothing, got %v", phrases)
+	}
+	if phrases := Take(gen, 0); len(phrases) != 0 {
+		t.Errorf("Expected no phrases for n = 0, got %v", phrases)
+	}
+}
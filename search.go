@@ -0,0 +1,457 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// defaultSearchWordCount is the phrase length searched when
+// SearchOptions.WordCount is zero
+const defaultSearchWordCount = 12
+
+// DefaultProgressInterval is how many candidates are tried between progress
+// callbacks when SearchOptions.ProgressInterval is zero
+const DefaultProgressInterval = 10000
+
+// ProgressFunc receives the number of candidates a search has tried so far
+// and the index vector of the candidate that crossed the reporting interval
+type ProgressFunc func(processed uint64, current []int)
+
+// SearchOptions configures an address search
+type SearchOptions struct {
+	Workers          int          // number of worker goroutines, at least 1
+	WordCount        int          // phrase length, 12 if zero
+	Progress         ProgressFunc // optional progress callback
+	ProgressInterval uint64       // candidates between Progress calls
+	Logger           *slog.Logger // optional; logs the search and, without Progress, its progress
+
+	// Deriver, with the full Path it derives at, searches the addresses of
+	// any coin, e.g. GetDeriver("eth") with m/44'/60'/0'/0/0. Targets must
+	// be written exactly as the Deriver encodes them. If nil, each
+	// target's Bitcoin AddressType picks the derivation.
+	Deriver Deriver
+	Path    []uint32
+}
+
+// searchDerivation is one address derived from every search candidate
+type searchDerivation struct {
+	deriver Deriver
+	path    []uint32
+}
+
+// bitcoinDerivation returns the derivation of the first mainnet address of
+// kind, at m/purpose'/0'/0'/0/0
+func bitcoinDerivation(kind AddressKind) searchDerivation {
+	return searchDerivation{
+		deriver: btcDeriver{kind: kind, params: &chaincfg.MainNetParams},
+		path:    childPath(externalChain(kind.Purpose(), chaincfg.MainNetParams.HDCoinType), 0),
+	}
+}
+
+// optionsDerivation returns the derivation configured by opts.Deriver and
+// opts.Path, or false if opts leaves the derivation to the targets
+func optionsDerivation(opts SearchOptions) (searchDerivation, bool, error) {
+	if opts.Deriver == nil {
+		return searchDerivation{}, false, nil
+	}
+	if len(opts.Path) == 0 {
+		return searchDerivation{}, false, fmt.Errorf("search path is empty")
+	}
+	return searchDerivation{deriver: opts.Deriver, path: opts.Path}, true, nil
+}
+
+// searchRateWindow is the window over which logged search rates are averaged
+const searchRateWindow = 10 * time.Second
+
+// discardLogger is used when SearchOptions.Logger is nil
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// progressReporter counts candidates across workers and invokes the
+// progress callback every interval candidates. Calls are serialized so the
+// callback does not need to be safe for concurrent use.
+type progressReporter struct {
+	fn        ProgressFunc
+	interval  uint64
+	processed atomic.Uint64
+	mu        sync.Mutex
+}
+
+func newProgressReporter(fn ProgressFunc, interval uint64) *progressReporter {
+	if interval == 0 {
+		interval = DefaultProgressInterval
+	}
+	return &progressReporter{fn: fn, interval: interval}
+}
+
+// tick records one more tried candidate
+func (p *progressReporter) tick(current []int) {
+	n := p.processed.Add(1)
+	if p.fn != nil && n%p.interval == 0 {
+		p.mu.Lock()
+		p.fn(n, append([]int(nil), current...))
+		p.mu.Unlock()
+	}
+}
+
+// SearchForAddress brute-forces 12-word combinations of the loaded wordlist
+// looking for the mnemonic whose first address is target. The derivation path
+// follows the target's AddressType: BIP44 for 1..., BIP49 for 3..., BIP84 for
+// bc1q... and BIP86 for bc1p... addresses. The space is sharded across
+// workers goroutines by the index of the first word; once a match is found
+// the remaining workers are cancelled.
+func SearchForAddress(target string, workers int) (string, bool) {
+	return SearchForAddressWithOptions(target, SearchOptions{Workers: workers})
+}
+
+// SearchForAddressWithOptions is SearchForAddress with a configurable phrase
+// length and progress reporting. A WordCount that is not a BIP39 phrase
+// length never matches.
+func SearchForAddressWithOptions(target string, opts SearchOptions) (string, bool) {
+	return SearchForAddressContext(context.Background(), target, opts)
+}
+
+// SearchForAddressContext is SearchForAddressWithOptions that also stops,
+// draining its workers, when ctx is cancelled. Without opts.Deriver, a target
+// that AddressType rejects never matches, so check it first to tell a typo
+// from a search that found nothing.
+func SearchForAddressContext(ctx context.Context, target string, opts SearchOptions) (string, bool) {
+	derivation, ok, err := optionsDerivation(opts)
+	if err != nil {
+		return "", false
+	}
+	if !ok {
+		// Derive along the path matching the target's type
+		kind, err := AddressType(target, &chaincfg.MainNetParams)
+		if err != nil {
+			return "", false
+		}
+		derivation = bitcoinDerivation(kind)
+	}
+
+	var mu sync.Mutex
+	var result string
+	found := false
+
+	err = runSearch(ctx, opts, []searchDerivation{derivation}, func(mnemonic, address string) bool {
+		if address != target {
+			return false
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if !found {
+			result, found = mnemonic, true
+		}
+		return true
+	})
+	if err != nil {
+		return "", false
+	}
+
+	return result, found
+}
+
+// SearchForAddresses brute-forces 12-word combinations of the loaded
+// wordlist like SearchForAddress, checking every derived address against the
+// whole targets set. It returns a map from each matched address to the
+// mnemonic that produced it, stopping early once every target is found.
+func SearchForAddresses(targets map[string]struct{}, workers int) (map[string]string, error) {
+	return SearchForAddressesWithOptions(context.Background(), targets, SearchOptions{Workers: workers})
+}
+
+// SearchForAddressesWithOptions is SearchForAddresses with the options of
+// SearchForAddressWithOptions. When ctx is cancelled the matches found so far
+// are returned.
+func SearchForAddressesWithOptions(ctx context.Context, targets map[string]struct{}, opts SearchOptions) (map[string]string, error) {
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no target addresses")
+	}
+
+	derivation, ok, err := optionsDerivation(opts)
+	if err != nil {
+		return nil, err
+	}
+	derivations := []searchDerivation{derivation}
+	if !ok {
+		// Only derive the address types that occur among the targets
+		present := make(map[AddressKind]bool)
+		for address := range targets {
+			kind, err := AddressType(address, &chaincfg.MainNetParams)
+			if err != nil {
+				return nil, err
+			}
+			present[kind] = true
+		}
+		derivations = nil
+		for _, kind := range []AddressKind{P2PKH, P2SH, P2WPKH, P2TR} {
+			if present[kind] {
+				derivations = append(derivations, bitcoinDerivation(kind))
+			}
+		}
+	}
+
+	var mu sync.Mutex
+	matches := make(map[string]string)
+
+	err = runSearch(ctx, opts, derivations, func(mnemonic, address string) bool {
+		if _, ok := targets[address]; !ok {
+			return false
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		matches[address] = mnemonic
+		return len(matches) == len(targets)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+// LoadAddressSet reads target addresses, one per line, from r. Surrounding
+// whitespace is trimmed and empty lines are skipped.
+func LoadAddressSet(r io.Reader) (map[string]struct{}, error) {
+	targets := make(map[string]struct{})
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		address := strings.TrimSpace(scanner.Text())
+		if address != "" {
+			targets[address] = struct{}{}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading addresses: %v", err)
+	}
+
+	return targets, nil
+}
+
+// runSearch shards the combination space across opts.Workers goroutines by
+// the index of the first word and calls visit with the address of each of
+// derivations for every valid candidate. visit may be called concurrently;
+// returning true stops the whole search.
+func runSearch(ctx context.Context, opts SearchOptions, derivations []searchDerivation, visit func(mnemonic, address string) bool) error {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+	wordCount := opts.WordCount
+	if wordCount == 0 {
+		wordCount = defaultSearchWordCount
+	}
+	if !isValidWordCount(wordCount) {
+		return fmt.Errorf("invalid word count: %d", wordCount)
+	}
+	logger := opts.Logger
+	if logger == nil {
+		logger = discardLogger
+	}
+	progressFn := opts.Progress
+	if progressFn == nil && opts.Logger != nil {
+		meter := NewRateMeter(searchRateWindow)
+		total := CombinationCount(len(BIP39Words), wordCount)
+		var last uint64
+
+		// Calls are serialized by the progress reporter, but workers may
+		// deliver their counts slightly out of order
+		progressFn = func(processed uint64, current []int) {
+			if processed > last {
+				meter.Tick(processed - last)
+				last = processed
+			}
+
+			remaining := new(big.Int).Sub(total, new(big.Int).SetUint64(processed))
+			logger.Info("search progress", "processed", processed, "rate", formatRate(meter.Rate()),
+				"eta", meter.ETA(remaining).Round(time.Second), "current", formatIndices(current))
+		}
+	}
+	progress := newProgressReporter(progressFn, opts.ProgressInterval)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	logger.Info("search started", "workers", workers, "word_count", wordCount)
+	defer func() {
+		logger.Info("search finished", "processed", progress.processed.Load())
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(shard int) {
+			defer wg.Done()
+			searchShard(ctx, wordCount, shard, workers, progress, derivations, func(mnemonic, address string) bool {
+				if visit(mnemonic, address) {
+					cancel()
+					return true
+				}
+				return false
+			})
+		}(w)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// searchShard visits every combination of wordCount words whose first word
+// index is congruent to shard modulo shards, until the context is cancelled
+// or visit returns true
+func searchShard(ctx context.Context, wordCount, shard, shards int, progress *progressReporter, derivations []searchDerivation, visit func(mnemonic, address string) bool) {
+	listSize := len(BIP39Words)
+	indices := make([]int, wordCount)
+
+	for first := shard; first+wordCount <= listSize; first += shards {
+		for i := range indices {
+			indices[i] = first + i
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			mnemonic := strings.Join(indicesToMnemonic(indices), " ")
+			// Most combinations fail the checksum and are rejected here
+			if addresses, err := candidateAddresses(mnemonic, derivations); err == nil {
+				for _, address := range addresses {
+					if visit(mnemonic, address) {
+						return
+					}
+				}
+			}
+			progress.tick(indices)
+
+			if !nextCombination(indices, listSize) || indices[0] != first {
+				break
+			}
+		}
+	}
+}
+
+// candidateAddresses validates a search candidate and derives its address
+// for each of derivations, computing the seed once for all of them
+func candidateAddresses(mnemonic string, derivations []searchDerivation) ([]string, error) {
+	if !IsMnemonicValid(mnemonic) {
+		return nil, ErrInvalidMnemonic
+	}
+	seed := newSeed(mnemonic, "")
+	defer Zero(seed)
+
+	addresses := make([]string, len(derivations))
+	for i, derivation := range derivations {
+		address, err := derivation.deriver.Address(seed, derivation.path)
+		if err != nil {
+			return nil, err
+		}
+		addresses[i] = address
+	}
+	return addresses, nil
+}
+
+// SearchWithPrefix brute-forces the 12-word mnemonics that start with
+// prefixWords, looking for the one whose first address on the given network
+// is target. The derivation path follows the target's AddressType as in
+// SearchForAddress. Unlike SearchForAddress the remaining positions range
+// over every word of the loaded wordlist, repeats included, since a
+// remembered prefix comes from a real phrase rather than a sorted
+// combination. Each known word divides the space by the wordlist size. The
+// first unknown position is sharded across workers goroutines. It returns
+// false if prefixWords is empty, has a word missing from the wordlist or no
+// phrase matches.
+func SearchWithPrefix(prefixWords []string, target string, workers int, params *chaincfg.Params) (string, bool) {
+	kind, err := AddressType(target, params)
+	if err != nil {
+		return "", false
+	}
+	if len(prefixWords) > defaultSearchWordCount {
+		return "", false
+	}
+	if _, err := phraseIndices(prefixWords); err != nil {
+		return "", false
+	}
+	if workers <= 0 {
+		workers = 1
+	}
+
+	matches := func(words []string) bool {
+		addresses, err := firstAddresses(strings.Join(words, " "), []AddressKind{kind}, params)
+		return err == nil && addresses[0] == target
+	}
+
+	free := defaultSearchWordCount - len(prefixWords)
+	if free == 0 {
+		if matches(prefixWords) {
+			return strings.Join(prefixWords, " "), true
+		}
+		return "", false
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	var result string
+	found := false
+
+	listSize := len(BIP39Words)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(shard int) {
+			defer wg.Done()
+
+			phrase := append(append([]string(nil), prefixWords...), make([]string, free)...)
+			indices := make([]int, free)
+			for first := shard; first < listSize; first += workers {
+				indices[0] = first
+				for i := 1; i < free; i++ {
+					indices[i] = 0
+				}
+
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					default:
+					}
+
+					for i, idx := range indices {
+						phrase[len(prefixWords)+i] = BIP39Words[idx]
+					}
+					if matches(phrase) {
+						mu.Lock()
+						if !found {
+							result, found = strings.Join(phrase, " "), true
+						}
+						mu.Unlock()
+						cancel()
+						return
+					}
+
+					if !nextOdometer(indices[1:], listSize) {
+						break
+					}
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	return result, found
+}
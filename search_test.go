@@ -0,0 +1,266 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// searchTestWords is a tiny wordlist containing the words of a known
+// mnemonic in phrase order, interleaved with a few extra words
+var searchTestWords = []string{
+	"ability", "mother", "author", "steel", "zoo", "speak", "help",
+	"absurd", "feature", "flee", "photo", "distance", "broken", "long",
+}
+
+func TestSearchForAddress(t *testing.T) {
+	useTestWords(t, searchTestWords)
+
+	expected := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	for _, workers := range []int{1, 3} {
+		mnemonic, ok := SearchForAddress("19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD", workers)
+		if !ok {
+			t.Fatalf("%d workers: expected to find the mnemonic", workers)
+		}
+		if mnemonic != expected {
+			t.Errorf("%d workers: expected mnemonic %q, got %q", workers, expected, mnemonic)
+		}
+	}
+}
+
+func TestSearchForAddress_NotFound(t *testing.T) {
+	useTestWords(t, searchTestWords)
+
+	if mnemonic, ok := SearchForAddress("1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA", 2); ok {
+		t.Fatalf("Expected no match, got %q", mnemonic)
+	}
+}
+
+func TestSearchForAddressWithOptions_Progress(t *testing.T) {
+	useTestWords(t, searchTestWords)
+
+	var calls []uint64
+	opts := SearchOptions{
+		Workers: 2,
+		Progress: func(processed uint64, current []int) {
+			if len(current) != defaultSearchWordCount {
+				t.Errorf("Expected %d indices, got %d", defaultSearchWordCount, len(current))
+			}
+			calls = append(calls, processed)
+		},
+		ProgressInterval: 10,
+	}
+
+	// No match, so all 14 choose 12 = 91 candidates are tried
+	if _, ok := SearchForAddressWithOptions("1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA", opts); ok {
+		t.Fatalf("Expected no match")
+	}
+
+	if len(calls) != 9 {
+		t.Fatalf("Expected 9 progress calls, got %d", len(calls))
+	}
+
+	// Workers may report out of order
+	sort.Slice(calls, func(i, j int) bool { return calls[i] < calls[j] })
+	for i, processed := range calls {
+		if processed != uint64(i+1)*10 {
+			t.Errorf("Expected call %d to report %d, got %d", i, (i+1)*10, processed)
+		}
+	}
+}
+
+func TestSearchForAddressWithOptions_InvalidWordCount(t *testing.T) {
+	useTestWords(t, searchTestWords)
+
+	if _, ok := SearchForAddressWithOptions("19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD", SearchOptions{WordCount: 13}); ok {
+		t.Fatalf("Expected no match for a 13-word search")
+	}
+}
+
+func TestSearchForAddresses(t *testing.T) {
+	useTestWords(t, searchTestWords)
+
+	targets := map[string]struct{}{
+		"19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD": {},
+		"1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA": {}, // not reachable from this wordlist
+	}
+
+	matches, err := SearchForAddresses(targets, 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(matches) != 1 {
+		t.Fatalf("Expected 1 match, got %v", matches)
+	}
+	expected := "mother author steel speak help absurd feature flee photo distance broken long"
+	if matches["19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD"] != expected {
+		t.Errorf("Expected mnemonic %q, got %q", expected, matches["19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD"])
+	}
+
+	if _, err := SearchForAddresses(map[string]struct{}{}, 1); err == nil {
+		t.Errorf("Expected an error for an empty target set")
+	}
+}
+
+func TestLoadAddressSet(t *testing.T) {
+	targets, err := LoadAddressSet(strings.NewReader("1abc\n\n  1def \n1abc\n"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(targets) != 2 {
+		t.Fatalf("Expected 2 addresses, got %d", len(targets))
+	}
+	for _, address := range []string{"1abc", "1def"} {
+		if _, ok := targets[address]; !ok {
+			t.Errorf("Expected %s in the address set", address)
+		}
+	}
+}
+
+func TestSearchForAddressContext_Cancelled(t *testing.T) {
+	useTestWords(t, searchTestWords)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, ok := SearchForAddressContext(ctx, "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD", SearchOptions{Workers: 2})
+	if ok {
+		t.Errorf("Expected a cancelled search to find nothing")
+	}
+}
+
+func TestSearchForAddressWithOptions_Logger(t *testing.T) {
+	useTestWords(t, searchTestWords)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	_, ok := SearchForAddressWithOptions("1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA", SearchOptions{
+		Workers:          2,
+		ProgressInterval: 30,
+		Logger:           logger,
+	})
+	if ok {
+		t.Fatalf("Expected no match")
+	}
+
+	// C(14, 12) = 91 candidates: 3 progress lines between start and finish
+	logs := buf.String()
+	for _, msg := range []string{"search started", "search finished", "processed=91"} {
+		if !strings.Contains(logs, msg) {
+			t.Errorf("Expected the logs to contain %q, got:\n%s", msg, logs)
+		}
+	}
+	if n := strings.Count(logs, "search progress"); n != 3 {
+		t.Errorf("Expected 3 progress lines, got %d:\n%s", n, logs)
+	}
+}
+
+func TestSearchForAddress_AddressTypes(t *testing.T) {
+	useTestWords(t, searchTestWords)
+
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+	for _, generate := range []func(string) (string, error){
+		GenerateBTCAddressNestedSegWit,
+		GenerateBTCAddressBech32,
+		GenerateBTCAddressTaproot,
+	} {
+		target, err := generate(mnemonic)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		found, ok := SearchForAddress(target, 2)
+		if !ok || found != mnemonic {
+			t.Errorf("%s: expected %q, got %q (found %v)", target, mnemonic, found, ok)
+		}
+	}
+
+	if _, ok := SearchForAddress("not an address", 2); ok {
+		t.Errorf("Expected no match for an invalid target")
+	}
+	if _, err := SearchForAddresses(map[string]struct{}{"not an address": {}}, 2); err == nil {
+		t.Errorf("Expected an error for an invalid target")
+	}
+}
+
+func TestSearchWithPrefix(t *testing.T) {
+	useTestWords(t, []string{"abandon", "ability", "able", "about"})
+
+	prefix := strings.Fields("abandon abandon abandon abandon abandon abandon abandon abandon abandon")
+	expected := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	for _, workers := range []int{1, 3} {
+		mnemonic, ok := SearchWithPrefix(prefix, "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA", workers, &chaincfg.MainNetParams)
+		if !ok {
+			t.Fatalf("%d workers: expected to find the mnemonic", workers)
+		}
+		if mnemonic != expected {
+			t.Errorf("%d workers: expected mnemonic %q, got %q", workers, expected, mnemonic)
+		}
+	}
+
+	// The testnet address of the same mnemonic is found with testnet params
+	mnemonic, ok := SearchWithPrefix(prefix, "mkpZhYtJu2r87Js3pDiWJDmPte2NRZ8bJV", 2, &chaincfg.TestNet3Params)
+	if !ok || mnemonic != expected {
+		t.Errorf("Expected %q on testnet, got %q (%v)", expected, mnemonic, ok)
+	}
+}
+
+func TestSearchWithPrefix_NotFound(t *testing.T) {
+	useTestWords(t, []string{"abandon", "ability", "able", "about"})
+
+	prefix := strings.Fields("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon")
+
+	if mnemonic, ok := SearchWithPrefix(prefix, "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD", 2, &chaincfg.MainNetParams); ok {
+		t.Errorf("Expected no match, got %q", mnemonic)
+	}
+	if _, ok := SearchWithPrefix([]string{"abandon", "notaword"}, "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA", 2, &chaincfg.MainNetParams); ok {
+		t.Errorf("Expected no match for a prefix word missing from the wordlist")
+	}
+	if _, ok := SearchWithPrefix(prefix, "not an address", 2, &chaincfg.MainNetParams); ok {
+		t.Errorf("Expected no match for an invalid target")
+	}
+}
+
+func TestSearchForAddressWithOptions_Deriver(t *testing.T) {
+	useTestWords(t, searchTestWords)
+
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+	target, err := GenerateETHAddress(mnemonic)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	eth, _ := GetDeriver("eth")
+	opts := SearchOptions{Workers: 2, Deriver: eth, Path: childPath(externalChain(44, 60), 0)}
+
+	found, ok := SearchForAddressWithOptions(target, opts)
+	if !ok || found != mnemonic {
+		t.Fatalf("Expected %q, got %q (found %v)", mnemonic, found, ok)
+	}
+
+	matches, err := SearchForAddressesWithOptions(context.Background(), map[string]struct{}{target: {}}, opts)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if matches[target] != mnemonic {
+		t.Errorf("Expected %q, got %q", mnemonic, matches[target])
+	}
+
+	// A Deriver needs the path to derive at
+	if _, ok := SearchForAddressWithOptions(target, SearchOptions{Deriver: eth}); ok {
+		t.Errorf("Expected no match without a path")
+	}
+	if _, err := SearchForAddressesWithOptions(context.Background(), map[string]struct{}{target: {}}, SearchOptions{Deriver: eth}); err == nil {
+		t.Errorf("Expected an error without a path")
+	}
+}
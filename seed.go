@@ -0,0 +1,29 @@
+package main
+
+import (
+	"crypto/sha512"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// bip39Iterations is the PBKDF2 iteration count BIP39 specifies
+const bip39Iterations = 2048
+
+// NewSeedWithIterations derives a 64-byte seed from a mnemonic and passphrase
+// with PBKDF2-HMAC-SHA512 using the given iteration count, normalizing the
+// input like newSeed. An iteration count of zero or less uses the standard
+// 2048, producing the same seed as newSeed.
+//
+// Any other count produces a NON-STANDARD seed that no wallet will derive, so
+// funds sent to its addresses can only be recovered with this function and
+// the same count. It is meant for research into derivation cost only.
+func NewSeedWithIterations(mnemonic, passphrase string, iterations int) []byte {
+	if iterations <= 0 {
+		iterations = bip39Iterations
+	}
+
+	mnemonic = norm.NFKD.String(collapseWhitespace(mnemonic))
+	salt := "mnemonic" + norm.NFKD.String(passphrase)
+	return pbkdf2.Key([]byte(mnemonic), []byte(salt), iterations, 64, sha512.New)
+}
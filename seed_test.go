@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestNewSeedWithIterations(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	// BIP39 test vector for the passphrase "TREZOR"
+	expected := "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"
+	for _, iterations := range []int{2048, 0} {
+		seed := NewSeedWithIterations(mnemonic, "TREZOR", iterations)
+		if hex.EncodeToString(seed) != expected {
+			t.Errorf("%d iterations: expected seed %s, got %x", iterations, expected, seed)
+		}
+	}
+
+	if !bytes.Equal(NewSeedWithIterations(mnemonic, "", 0), newSeed(mnemonic, "")) {
+		t.Errorf("Expected the default iteration count to match newSeed")
+	}
+
+	if bytes.Equal(NewSeedWithIterations(mnemonic, "TREZOR", 1000), NewSeedWithIterations(mnemonic, "TREZOR", 2048)) {
+		t.Errorf("Expected a different iteration count to change the seed")
+	}
+}
+
+func BenchmarkNewSeedWithIterations(b *testing.B) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	for i := 0; i < b.N; i++ {
+		NewSeedWithIterations(mnemonic, "", 2048)
+	}
+}
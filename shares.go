@@ -0,0 +1,63 @@
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// SplitEntropy splits BIP39 entropy into the given number of XOR shares:
+// shares-1 random values plus one that XORs with them back to the entropy.
+// Every share has the entropy's length, so each can itself be written down as
+// a mnemonic with EntropyToMnemonic.
+//
+// This is all-or-nothing, not a threshold scheme like SLIP-39: every share is
+// required to recover the entropy, and losing any one of them loses the
+// wallet. Any subset short of all shares reveals nothing about the entropy.
+func SplitEntropy(entropy []byte, shares int) ([][]byte, error) {
+	if err := validateEntropyLength(entropy); err != nil {
+		return nil, err
+	}
+	if shares < 2 {
+		return nil, fmt.Errorf("invalid share count: %d, must be at least 2", shares)
+	}
+
+	result := make([][]byte, shares)
+	last := append([]byte(nil), entropy...)
+	for i := 0; i < shares-1; i++ {
+		share := make([]byte, len(entropy))
+		if _, err := rand.Read(share); err != nil {
+			return nil, fmt.Errorf("failed to read entropy: %v", err)
+		}
+		for j := range last {
+			last[j] ^= share[j]
+		}
+		result[i] = share
+	}
+	result[shares-1] = last
+
+	return result, nil
+}
+
+// CombineEntropy recovers the entropy split by SplitEntropy by XORing all of
+// its shares together. The shares must all have the same, BIP39 entropy,
+// length; their order does not matter.
+func CombineEntropy(shares [][]byte) ([]byte, error) {
+	if len(shares) < 2 {
+		return nil, fmt.Errorf("invalid share count: %d, must be at least 2", len(shares))
+	}
+	if err := validateEntropyLength(shares[0]); err != nil {
+		return nil, err
+	}
+
+	entropy := make([]byte, len(shares[0]))
+	for i, share := range shares {
+		if len(share) != len(entropy) {
+			return nil, fmt.Errorf("share %d has %d bytes, expected %d", i+1, len(share), len(entropy))
+		}
+		for j := range entropy {
+			entropy[j] ^= share[j]
+		}
+	}
+
+	return entropy, nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSplitEntropy(t *testing.T) {
+	entropy := bytes.Repeat([]byte{0x7f}, 32)
+
+	shares, err := SplitEntropy(entropy, 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(shares) != 3 {
+		t.Fatalf("Expected 3 shares, got %d", len(shares))
+	}
+	for i, share := range shares {
+		if len(share) != len(entropy) {
+			t.Errorf("Share %d: expected %d bytes, got %d", i, len(entropy), len(share))
+		}
+		if bytes.Equal(share, entropy) {
+			t.Errorf("Share %d equals the entropy", i)
+		}
+	}
+
+	// Every share is needed, in any order
+	combined, err := CombineEntropy([][]byte{shares[2], shares[0], shares[1]})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !bytes.Equal(combined, entropy) {
+		t.Errorf("Expected entropy %x, got %x", entropy, combined)
+	}
+
+	partial, err := CombineEntropy(shares[:2])
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if bytes.Equal(partial, entropy) {
+		t.Errorf("Expected two of three shares not to recover the entropy")
+	}
+}
+
+func TestSplitEntropy_Invalid(t *testing.T) {
+	if _, err := SplitEntropy(make([]byte, 16), 1); err == nil {
+		t.Errorf("Expected an error for a single share")
+	}
+	if _, err := SplitEntropy(make([]byte, 15), 2); err == nil {
+		t.Errorf("Expected an error for invalid entropy")
+	}
+
+	if _, err := CombineEntropy([][]byte{make([]byte, 16)}); err == nil {
+		t.Errorf("Expected an error for a single share")
+	}
+	if _, err := CombineEntropy([][]byte{make([]byte, 16), make([]byte, 20)}); err == nil {
+		t.Errorf("Expected an error for mismatched share lengths")
+	}
+}
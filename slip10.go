@@ -0,0 +1,46 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+)
+
+// ed25519SeedKey is the HMAC key SLIP-0010 uses to derive an ed25519 master key
+const ed25519SeedKey = "ed25519 seed"
+
+// DeriveED25519 derives the 32-byte ed25519 private key at path from a BIP39
+// seed following SLIP-0010, as used by coins such as Solana. ed25519 only
+// supports hardened derivation, so every level of path must include
+// hdkeychain.HardenedKeyStart.
+func DeriveED25519(seed []byte, path []uint32) ([]byte, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("invalid seed length: %d bytes, must be between 16 and 64", len(seed))
+	}
+
+	mac := hmac.New(sha512.New, []byte(ed25519SeedKey))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := sum[:32], sum[32:]
+
+	data := make([]byte, 37)
+	for i, child := range path {
+		if child < hdkeychain.HardenedKeyStart {
+			return nil, fmt.Errorf("level %d of the path is not hardened, ed25519 only supports hardened derivation", i+1)
+		}
+
+		// data = 0x00 || key || ser32(child)
+		copy(data[1:33], key)
+		binary.BigEndian.PutUint32(data[33:], child)
+
+		mac = hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum = mac.Sum(nil)
+		key, chainCode = sum[:32], sum[32:]
+	}
+
+	return key, nil
+}
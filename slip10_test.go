@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+)
+
+func TestDeriveED25519(t *testing.T) {
+	// SLIP-0010 ed25519 test vector 1
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	h := uint32(hdkeychain.HardenedKeyStart)
+
+	tests := []struct {
+		path     []uint32
+		expected string
+	}{
+		{nil, "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7"},
+		{[]uint32{h}, "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3"},
+		{[]uint32{h, h + 1}, "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2"},
+		{[]uint32{h, h + 1, h + 2}, "92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9"},
+		{[]uint32{h, h + 1, h + 2, h + 2}, "30d1dc7e5fc04c31219ab25a27ae00b50f6fd66622f6e9c913253d6511d1e662"},
+		{[]uint32{h, h + 1, h + 2, h + 2, h + 1000000000}, "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793"},
+	}
+
+	for _, tt := range tests {
+		key, err := DeriveED25519(seed, tt.path)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", PathToString(tt.path), err)
+		}
+		if hex.EncodeToString(key) != tt.expected {
+			t.Errorf("%s: expected %s, got %x", PathToString(tt.path), tt.expected, key)
+		}
+	}
+}
+
+func TestDeriveED25519_Invalid(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+	if _, err := DeriveED25519(seed, []uint32{hdkeychain.HardenedKeyStart, 1}); err == nil {
+		t.Errorf("Expected an error for a non-hardened level")
+	}
+	if _, err := DeriveED25519(seed[:8], nil); err == nil {
+		t.Errorf("Expected an error for a short seed")
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// generatorState is the on-disk checkpoint of a generator run
+type generatorState struct {
+	Indices []int `json:"indices"`
+}
+
+// SaveGeneratorState writes the generator's current index slice to path as
+// JSON so that an interrupted run can be resumed with LoadGeneratorState.
+// The file is replaced atomically so a crash never leaves a partial checkpoint.
+func SaveGeneratorState(indices []int, path string) error {
+	data, err := json.Marshal(generatorState{Indices: indices})
+	if err != nil {
+		return fmt.Errorf("failed to encode generator state: %v", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write generator state: %v", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write generator state: %v", err)
+	}
+
+	return nil
+}
+
+// LoadGeneratorState reads an index slice written by SaveGeneratorState and
+// checks that it has a BIP39 phrase length and only in-range word indices
+func LoadGeneratorState(path string) ([]int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read generator state: %v", err)
+	}
+
+	var state generatorState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode generator state: %v", err)
+	}
+
+	if !isValidWordCount(len(state.Indices)) {
+		return nil, fmt.Errorf("generator state has %d indices, expected 12, 15, 18, 21 or 24", len(state.Indices))
+	}
+	for i, idx := range state.Indices {
+		if idx < 0 || idx >= BIP39WordlistSize {
+			return nil, fmt.Errorf("generator state index %d out of range: %d", i, idx)
+		}
+	}
+
+	return state.Indices, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveLoadGeneratorState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	indices := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 2047}
+
+	if err := SaveGeneratorState(indices, path); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	loaded, err := LoadGeneratorState(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(loaded) != len(indices) {
+		t.Fatalf("Expected %d indices, got %d", len(indices), len(loaded))
+	}
+	for i := range indices {
+		if loaded[i] != indices[i] {
+			t.Errorf("Expected index %d to be %d, got %d", i, indices[i], loaded[i])
+		}
+	}
+}
+
+func TestLoadGeneratorState_Invalid(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := map[string]string{
+		"malformed":    `{"indices":`,
+		"short":        `{"indices":[0,1,2]}`,
+		"out of range": `{"indices":[0,1,2,3,4,5,6,7,8,9,10,2048]}`,
+		"negative":     `{"indices":[-1,1,2,3,4,5,6,7,8,9,10,11]}`,
+	}
+
+	for name, content := range tests {
+		path := filepath.Join(dir, name+".json")
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to write state file: %v", err)
+		}
+
+		if _, err := LoadGeneratorState(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if _, err := LoadGeneratorState(filepath.Join(dir, "missing.json")); err == nil {
+		t.Errorf("Expected an error for a missing file")
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+)
+
+// GenerateMnemonics streams the phrases of mnemonicGenerator onto the
+// returned channel, starting at start (or the first 12-word combination when
+// start is nil). The channel is closed once the space is exhausted, the
+// context is cancelled, or start is not a valid starting point.
+func GenerateMnemonics(ctx context.Context, start []int) <-chan []string {
+	out := make(chan []string)
+
+	wordCount := len(start)
+	if start == nil {
+		wordCount = 12
+	}
+
+	go func() {
+		defer close(out)
+
+		gen, err := mnemonicGenerator(start, wordCount, Combinations)
+		if err != nil {
+			return
+		}
+
+		for {
+			phrase, more := gen()
+			select {
+			case out <- phrase:
+			case <-ctx.Done():
+				return
+			}
+			if !more {
+				return
+			}
+		}
+	}()
+
+	return out
+}
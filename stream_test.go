@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGenerateMnemonics(t *testing.T) {
+	useTestWords(t, []string{"abandon", "ability", "able", "about", "above"})
+
+	count := 0
+	for phrase := range GenerateMnemonics(context.Background(), []int{0, 1, 2}) {
+		if len(phrase) != 3 {
+			t.Fatalf("Expected 3 words, got %d", len(phrase))
+		}
+		count++
+	}
+
+	// 5 choose 3
+	if count != 10 {
+		t.Errorf("Expected 10 phrases, got %d", count)
+	}
+}
+
+func TestGenerateMnemonics_Cancel(t *testing.T) {
+	useEnglishWordlist(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	phrases := GenerateMnemonics(ctx, nil)
+
+	for i := 0; i < 5; i++ {
+		if phrase := <-phrases; len(phrase) != 12 {
+			t.Fatalf("Expected 12 words, got %d", len(phrase))
+		}
+	}
+	cancel()
+
+	// The channel is closed shortly after cancellation
+	for range phrases {
+	}
+}
+
+func TestGenerateMnemonics_InvalidStart(t *testing.T) {
+	useTestWords(t, []string{"abandon", "ability", "able", "about", "above"})
+
+	for phrase := range GenerateMnemonics(context.Background(), []int{2, 1, 0}) {
+		t.Fatalf("Expected no phrases, got %v", phrase)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// Summary is the overview WalletSummary returns, ready to be marshalled to
+// JSON for a frontend
+type Summary struct {
+	Accounts []AccountSummary `json:"accounts"`
+}
+
+// AccountSummary holds the first addresses of one account number. Addresses
+// is keyed by "bip44", "bip49", "bip84" and "bip86" like AllAddressTypes.
+type AccountSummary struct {
+	Account   uint32                 `json:"account"`
+	Addresses map[string]AddressPair `json:"addresses"`
+}
+
+// AddressPair is the first receiving (m/.../0/0) and change (m/.../1/0)
+// address of an account
+type AddressPair struct {
+	Receive string `json:"receive"`
+	Change  string `json:"change"`
+}
+
+// WalletSummary derives, for each of the first accounts accounts of a
+// mnemonic on the given network and each of BIP44, BIP49, BIP84 and BIP86,
+// the first receiving and first change address. The seed is computed once
+// for the whole summary.
+func WalletSummary(mnemonic string, accounts int, params *chaincfg.Params) (Summary, error) {
+	if accounts <= 0 {
+		return Summary{}, fmt.Errorf("invalid account count: %d", accounts)
+	}
+	if !IsMnemonicValid(mnemonic) {
+		return Summary{}, ErrInvalidMnemonic
+	}
+	seed := newSeed(mnemonic, "")
+	defer Zero(seed)
+
+	summary := Summary{Accounts: make([]AccountSummary, accounts)}
+	for account := range summary.Accounts {
+		addresses := make(map[string]AddressPair, 4)
+		for _, kind := range []AddressKind{P2PKH, P2SH, P2WPKH, P2TR} {
+			d := btcDeriver{kind: kind, params: params}
+			path := func(change uint32) []uint32 {
+				return []uint32{
+					Hardened(kind.Purpose()),
+					Hardened(params.HDCoinType),
+					Hardened(uint32(account)),
+					change,
+					0, // Address index: 0
+				}
+			}
+
+			receive, err := d.Address(seed, path(0))
+			if err != nil {
+				return Summary{}, err
+			}
+			change, err := d.Address(seed, path(1))
+			if err != nil {
+				return Summary{}, err
+			}
+			addresses[fmt.Sprintf("bip%d", kind.Purpose())] = AddressPair{Receive: receive, Change: change}
+		}
+		summary.Accounts[account] = AccountSummary{Account: uint32(account), Addresses: addresses}
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestWalletSummary(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	summary, err := WalletSummary(mnemonic, 2, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(summary.Accounts) != 2 {
+		t.Fatalf("Expected 2 accounts, got %d", len(summary.Accounts))
+	}
+
+	// The receiving addresses of account 0 match AllAddressTypes
+	first, err := AllAddressTypes(mnemonic, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for key, want := range first {
+		if got := summary.Accounts[0].Addresses[key].Receive; got != want {
+			t.Errorf("%s: expected receive address %s, got %s", key, want, got)
+		}
+	}
+
+	// First change address of the BIP84 test vector
+	if got := summary.Accounts[0].Addresses["bip84"].Change; got != "bc1q8c6fshw2dlwun7ekn9qwf37cu2rn755upcp6el" {
+		t.Errorf("Expected change address bc1q8c6fshw2dlwun7ekn9qwf37cu2rn755upcp6el, got %s", got)
+	}
+
+	second := summary.Accounts[1]
+	if second.Account != 1 || second.Addresses["bip44"].Receive == first["bip44"] {
+		t.Errorf("Expected account 1 to have its own addresses, got %+v", second)
+	}
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var decoded Summary
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if decoded.Accounts[1].Addresses["bip86"] != summary.Accounts[1].Addresses["bip86"] {
+		t.Errorf("Expected the summary to round-trip through JSON, got %s", data)
+	}
+}
+
+func TestWalletSummary_Invalid(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	if _, err := WalletSummary(mnemonic, 0, &chaincfg.MainNetParams); err == nil {
+		t.Errorf("Expected an error for zero accounts")
+	}
+	if _, err := WalletSummary("invalid mnemonic phrase", 1, &chaincfg.MainNetParams); err == nil {
+		t.Errorf("Expected an error for invalid mnemonic")
+	}
+}
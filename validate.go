@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	bip39 "github.com/tyler-smith/go-bip39"
+)
+
+// ErrChecksumMismatch is returned when every word of a mnemonic is known but
+// the phrase fails the BIP39 checksum
+var ErrChecksumMismatch = errors.New("mnemonic checksum mismatch")
+
+// ErrInvalidWordCount is returned when a mnemonic does not have 12, 15, 18,
+// 21 or 24 words
+type ErrInvalidWordCount struct {
+	Count int
+}
+
+func (e ErrInvalidWordCount) Error() string {
+	return fmt.Sprintf("mnemonic has %d words, expected 12, 15, 18, 21 or 24", e.Count)
+}
+
+// ErrUnknownWord is returned when a mnemonic contains a word that is not in
+// the wordlist. Index is the zero-based position of the word in the phrase.
+type ErrUnknownWord struct {
+	Index int
+	Word  string
+}
+
+func (e ErrUnknownWord) Error() string {
+	return fmt.Sprintf("word %d (%q) is not in the wordlist", e.Index+1, e.Word)
+}
+
+// ErrInvalidMnemonic is returned by the address generators when a mnemonic
+// fails BIP39 validation; use ValidateMnemonicDetailed to find out why
+var ErrInvalidMnemonic = errors.New("invalid mnemonic")
+
+// ErrAddressEncode is wrapped by the address generators when a derived key
+// cannot be encoded as an address
+var ErrAddressEncode = errors.New("failed to encode address")
+
+// ErrDerivation is returned when BIP32 derivation fails. Depth is the level
+// of the path that failed: 0 for the master key, 1 for the first child and
+// so on. Err is the underlying hdkeychain error.
+type ErrDerivation struct {
+	Depth int
+	Err   error
+}
+
+func (e ErrDerivation) Error() string {
+	if e.Depth == 0 {
+		return fmt.Sprintf("failed to create master key: %v", e.Err)
+	}
+	return fmt.Sprintf("failed to derive child key at depth %d: %v", e.Depth, e.Err)
+}
+
+func (e ErrDerivation) Unwrap() error {
+	return e.Err
+}
+
+// ValidateMnemonicDetailed validates a mnemonic against the loaded wordlist
+// and reports why it is invalid: an ErrInvalidWordCount, an ErrUnknownWord
+// for the first unknown word, or ErrChecksumMismatch
+func ValidateMnemonicDetailed(mnemonic string) error {
+	words := strings.Fields(mnemonic)
+	if !isValidWordCount(len(words)) {
+		return ErrInvalidWordCount{Count: len(words)}
+	}
+
+	indices := make([]int, len(words))
+	for i, word := range words {
+		idx, ok := WordIndex(word)
+		if !ok {
+			return ErrUnknownWord{Index: i, Word: word}
+		}
+		indices[i] = idx
+	}
+
+	if len(BIP39Words) != BIP39WordlistSize {
+		return fmt.Errorf("checksum computation requires a %d-word wordlist, got %d", BIP39WordlistSize, len(BIP39Words))
+	}
+	if !hasValidChecksum(indices) {
+		return ErrChecksumMismatch
+	}
+
+	return nil
+}
+
+// MnemonicScore rates how BIP39-like a possibly garbled phrase is: the
+// fraction of words found in the loaded wordlist, from 0 to 1, and whether
+// the phrase is complete with a valid checksum. It is cheap enough to triage
+// large lists of OCR'd or half-remembered phrases before running recovery.
+func MnemonicScore(words []string) (float64, bool) {
+	if len(words) == 0 {
+		return 0, false
+	}
+
+	known := 0
+	indices := make([]int, 0, len(words))
+	for _, word := range words {
+		if idx, ok := WordIndex(word); ok {
+			known++
+			indices = append(indices, idx)
+		}
+	}
+	score := float64(known) / float64(len(words))
+
+	valid := known == len(words) && isValidWordCount(len(words)) &&
+		len(BIP39Words) == BIP39WordlistSize && hasValidChecksum(indices)
+	return score, valid
+}
+
+// NormalizeMnemonic cleans up a pasted phrase: it lowercases it, trims it and
+// collapses every run of whitespace, including tabs and newlines, into a
+// single space. Run user input through it before GenerateBTCAddress or
+// validation, which otherwise reject mixed-case words.
+func NormalizeMnemonic(s string) string {
+	return collapseWhitespace(strings.ToLower(s))
+}
+
+// IsMnemonicValid reports whether mnemonic is a valid BIP39 phrase of the
+// current wordlist, the one selected with SetLanguage or UseWordlist. Words
+// may be separated by any run of whitespace, so a phrase pasted with
+// double spaces, tabs or line breaks validates like its single-spaced form.
+func IsMnemonicValid(mnemonic string) bool {
+	return bip39.IsMnemonicValid(collapseWhitespace(mnemonic))
+}
+
+// collapseWhitespace trims s and joins its whitespace-separated fields with
+// single spaces
+func collapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	bip39 "github.com/tyler-smith/go-bip39"
+)
+
+func TestValidateMnemonicDetailed(t *testing.T) {
+	useEnglishWordlist(t)
+
+	valid := []string{
+		"mother author steel speak help absurd feature flee photo distance broken long",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+	}
+	for _, mnemonic := range valid {
+		if err := ValidateMnemonicDetailed(mnemonic); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", mnemonic, err)
+		}
+	}
+}
+
+func TestValidateMnemonicDetailed_Errors(t *testing.T) {
+	useEnglishWordlist(t)
+
+	err := ValidateMnemonicDetailed("mother author steel")
+	var countErr ErrInvalidWordCount
+	if !errors.As(err, &countErr) || countErr.Count != 3 {
+		t.Errorf("Expected ErrInvalidWordCount{3}, got %v", err)
+	}
+
+	err = ValidateMnemonicDetailed("mother author steel speak help absurd featur flee photo distance broken long")
+	var wordErr ErrUnknownWord
+	if !errors.As(err, &wordErr) {
+		t.Fatalf("Expected ErrUnknownWord, got %v", err)
+	}
+	if wordErr.Index != 6 || wordErr.Word != "featur" {
+		t.Errorf("Expected unknown word 6 (featur), got %d (%s)", wordErr.Index, wordErr.Word)
+	}
+
+	err = ValidateMnemonicDetailed("mother author steel speak help absurd feature flee photo distance broken broken")
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Expected ErrChecksumMismatch, got %v", err)
+	}
+}
+
+func FuzzValidateMnemonicDetailed(f *testing.F) {
+	useEnglishWordlist(f)
+
+	for _, seed := range []string{
+		"",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		"mother author steel speak help absurd featur flee photo distance broken long",
+		"abandon　abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, mnemonic string) {
+		err := ValidateMnemonicDetailed(mnemonic)
+
+		// The detailed validator must agree with the reference implementation
+		if valid := bip39.IsMnemonicValid(mnemonic); valid != (err == nil) {
+			t.Errorf("%q: bip39 reports valid=%v, ValidateMnemonicDetailed returned %v", mnemonic, valid, err)
+		}
+	})
+}
+
+func TestNormalizeMnemonic(t *testing.T) {
+	tests := map[string]string{
+		"  ABANDON\tabandon  able ": "abandon abandon able",
+		"abandon\nability\r\nable":  "abandon ability able",
+		"Mother Author":             "mother author",
+		"":                          "",
+		" \t ":                      "",
+	}
+
+	for input, expected := range tests {
+		if normalized := NormalizeMnemonic(input); normalized != expected {
+			t.Errorf("%q: expected %q, got %q", input, expected, normalized)
+		}
+	}
+
+	// A normalized phrase derives the same address as the clean one
+	address, err := GenerateBTCAddress(NormalizeMnemonic(" MOTHER author steel speak help absurd feature flee photo distance broken\tLong\n"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if address != "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD" {
+		t.Errorf("Expected address 19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD, got %s", address)
+	}
+}
+
+func TestIsMnemonicValid(t *testing.T) {
+	tests := []struct {
+		mnemonic string
+		expected bool
+	}{
+		{"mother author steel speak help absurd feature flee photo distance broken long", true},
+		{"mother  author\tsteel speak help absurd feature\nflee photo distance broken long ", true},
+		{"mother author steel speak help absurd feature flee photo distance broken broken", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsMnemonicValid(tt.mnemonic); got != tt.expected {
+			t.Errorf("%q: expected %v, got %v", tt.mnemonic, tt.expected, got)
+		}
+	}
+}
+
+func TestMnemonicScore(t *testing.T) {
+	useEnglishWordlist(t)
+
+	tests := []struct {
+		phrase string
+		score  float64
+		valid  bool
+	}{
+		{"mother author steel speak help absurd feature flee photo distance broken long", 1, true},
+		{"mother author steel speak help absurd feature flee photo distance broken broken", 1, false},
+		{"m0ther author steel speak help absurd featur flee photo distance broken long", 10.0 / 12, false},
+		{"mother author steel", 1, false},
+		{"lorem ipsum", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		score, valid := MnemonicScore(strings.Fields(tt.phrase))
+		if score != tt.score || valid != tt.valid {
+			t.Errorf("%q: expected (%v, %v), got (%v, %v)", tt.phrase, tt.score, tt.valid, score, valid)
+		}
+	}
+}
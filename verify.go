@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// VerifyMnemonicAddress reports whether the address derived from a mnemonic
+// at path on the given network is expectedAddress, proving that a backup
+// controls the address without loading it into a wallet. The key is encoded
+// as the AddressType of expectedAddress, so a bc1q... address is checked
+// against a P2WPKH encoding, and path should be the matching BIP44, 49, 84
+// or 86 path. A valid mnemonic that derives a different address, or an
+// expectedAddress that does not decode, yields false without an error;
+// errors are reserved for invalid mnemonics and failed derivations.
+func VerifyMnemonicAddress(mnemonic, expectedAddress string, path []uint32, params *chaincfg.Params) (bool, error) {
+	childKey, err := deriveKey(mnemonic, "", path, params)
+	if err != nil {
+		return false, err
+	}
+	defer childKey.Zero()
+
+	kind, err := AddressType(expectedAddress, params)
+	if err != nil {
+		return false, nil
+	}
+
+	address, err := encodeAddress(childKey, kind, params)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrAddressEncode, err)
+	}
+
+	return address == expectedAddress, nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestVerifyMnemonicAddress(t *testing.T) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+	path := []uint32{
+		hdkeychain.HardenedKeyStart + 44,
+		hdkeychain.HardenedKeyStart,
+		hdkeychain.HardenedKeyStart,
+		0,
+		0,
+	}
+
+	tests := []struct {
+		address  string
+		expected bool
+	}{
+		{"19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD", true},
+		{"1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		ok, err := VerifyMnemonicAddress(mnemonic, tt.address, path, &chaincfg.MainNetParams)
+		if err != nil {
+			t.Fatalf("%q: expected no error, got %v", tt.address, err)
+		}
+		if ok != tt.expected {
+			t.Errorf("%q: expected %v, got %v", tt.address, tt.expected, ok)
+		}
+	}
+}
+
+func TestVerifyMnemonicAddress_AddressTypes(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	tests := []struct {
+		purpose uint32
+		address string
+	}{
+		{44, "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA"},
+		{49, "37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf"},
+		{84, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"},
+		{86, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"},
+	}
+
+	for _, tt := range tests {
+		path := []uint32{Hardened(tt.purpose), Hardened(0), Hardened(0), 0, 0}
+		ok, err := VerifyMnemonicAddress(mnemonic, tt.address, path, &chaincfg.MainNetParams)
+		if err != nil {
+			t.Fatalf("Purpose %d: expected no error, got %v", tt.purpose, err)
+		}
+		if !ok {
+			t.Errorf("Purpose %d: expected %s to verify", tt.purpose, tt.address)
+		}
+	}
+
+	// The right encoding at the wrong path still does not match
+	path := []uint32{Hardened(44), Hardened(0), Hardened(0), 0, 0}
+	if ok, _ := VerifyMnemonicAddress(mnemonic, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu", path, &chaincfg.MainNetParams); ok {
+		t.Errorf("Expected a BIP84 address not to verify at a BIP44 path")
+	}
+}
+
+func TestVerifyMnemonicAddress_InvalidMnemonic(t *testing.T) {
+	_, err := VerifyMnemonicAddress("invalid mnemonic phrase", "19BmNcEn86JeZpSqjQAP1LMNzr36PvCdRD", nil, &chaincfg.MainNetParams)
+	if err == nil {
+		t.Fatalf("Expected an error for invalid mnemonic")
+	}
+}
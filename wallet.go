@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// Wallet caches the derived keys of a mnemonic so that repeated address
+// lookups skip the seed computation (PBKDF2 with 2048 iterations) and the
+// hardened part of the BIP44 path
+type Wallet struct {
+	params     *chaincfg.Params
+	masterKey  *hdkeychain.ExtendedKey
+	accountKey *hdkeychain.ExtendedKey // m/44'/coin'/0'
+}
+
+// NewWallet validates a mnemonic and derives and caches its master key and
+// first BIP44 account key for the given network
+func NewWallet(mnemonic, passphrase string, params *chaincfg.Params) (*Wallet, error) {
+	masterKey, err := deriveKey(mnemonic, passphrase, nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	accountKey := masterKey
+	for _, child := range []uint32{
+		hdkeychain.HardenedKeyStart + 44,                // BIP44 purpose
+		hdkeychain.HardenedKeyStart + params.HDCoinType, // Coin type: 0 for Bitcoin, 1 for testnets
+		hdkeychain.HardenedKeyStart,                     // Account: 0
+	} {
+		parent := accountKey
+		accountKey, err = parent.Derive(child)
+		// Only the master and account keys are cached
+		if parent != masterKey {
+			parent.Zero()
+		}
+		if err != nil {
+			masterKey.Zero()
+			return nil, fmt.Errorf("failed to derive child key: %v", err)
+		}
+	}
+
+	return &Wallet{params: params, masterKey: masterKey, accountKey: accountKey}, nil
+}
+
+// Close wipes the cached master and account keys. The wallet cannot derive
+// addresses afterwards. As with Zero this is best effort: it clears the
+// key material the wallet holds, not copies made elsewhere.
+func (w *Wallet) Close() {
+	if w.accountKey != nil {
+		w.accountKey.Zero()
+		w.accountKey = nil
+	}
+	if w.masterKey != nil {
+		w.masterKey.Zero()
+		w.masterKey = nil
+	}
+}
+
+// Address returns the receiving address m/44'/coin'/0'/0/index. Only the two
+// non-hardened derivations are performed per call.
+func (w *Wallet) Address(index uint32) (string, error) {
+	if w.accountKey == nil {
+		return "", fmt.Errorf("wallet is closed")
+	}
+
+	chainKey, err := w.accountKey.Derive(0) // Change: 0 for external chain
+	if err != nil {
+		return "", fmt.Errorf("failed to derive child key: %v", err)
+	}
+	defer chainKey.Zero()
+
+	childKey, err := chainKey.Derive(index)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive child key: %v", err)
+	}
+	defer childKey.Zero()
+
+	return p2pkhAddress(childKey, w.params)
+}
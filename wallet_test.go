@@ -0,0 +1,108 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestWalletAddress(t *testing.T) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	wallet, err := NewWallet(mnemonic, "", &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected, err := GenerateBTCAddresses(mnemonic, 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for i, want := range expected {
+		address, err := wallet.Address(uint32(i))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if address != want {
+			t.Errorf("Expected address %d to be %s, got %s", i, want, address)
+		}
+	}
+}
+
+func TestWalletAddress_TestNet(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	wallet, err := NewWallet(mnemonic, "", &chaincfg.TestNet3Params)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	address, err := wallet.Address(0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if address != "mkpZhYtJu2r87Js3pDiWJDmPte2NRZ8bJV" {
+		t.Errorf("Expected address %s, got %s", "mkpZhYtJu2r87Js3pDiWJDmPte2NRZ8bJV", address)
+	}
+}
+
+func TestNewWallet_InvalidMnemonic(t *testing.T) {
+	if _, err := NewWallet("invalid mnemonic phrase", "", &chaincfg.MainNetParams); err == nil {
+		t.Fatalf("Expected an error for invalid mnemonic")
+	}
+}
+
+func TestWalletClose(t *testing.T) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	wallet, err := NewWallet(mnemonic, "", &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := wallet.Address(0); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	wallet.Close()
+	if _, err := wallet.Address(0); err == nil {
+		t.Errorf("Expected an error deriving from a closed wallet")
+	}
+
+	// Closing twice is harmless
+	wallet.Close()
+}
+
+func BenchmarkWalletAddress(b *testing.B) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	wallet, err := NewWallet(mnemonic, "", &chaincfg.MainNetParams)
+	if err != nil {
+		b.Fatalf("Expected no error, got %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := wallet.Address(uint32(i % 100)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGenerateBTCAddressAtPathIndex(b *testing.B) {
+	mnemonic := "mother author steel speak help absurd feature flee photo distance broken long"
+
+	for i := 0; i < b.N; i++ {
+		path := []uint32{
+			hdkeychain.HardenedKeyStart + 44,
+			hdkeychain.HardenedKeyStart,
+			hdkeychain.HardenedKeyStart,
+			0,
+			uint32(i % 100),
+		}
+		if _, err := GenerateBTCAddressAtPath(mnemonic, path); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
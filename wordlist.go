@@ -0,0 +1,330 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// BIP39WordlistSize is the number of words in every BIP39 wordlist
+const BIP39WordlistSize = 2048
+
+// EnglishWordlistSHA256 is the SHA-256 of the official BIP39 english.txt,
+// one word per line with a trailing newline
+const EnglishWordlistSHA256 = "2f5eed53a4727b4bf8880d8f3f199efc90e58503646d9ff8eff3a2ed3b24dbda"
+
+// WordlistSHA256 returns the hex SHA-256 of words written one per line with a
+// trailing newline, the layout of the official wordlist files, so the result
+// can be compared against a published file checksum
+func WordlistSHA256(words []string) string {
+	h := sha256.New()
+	for _, word := range words {
+		h.Write([]byte(word))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// VerifyEnglishWordlist reports whether words is exactly the official BIP39
+// English wordlist
+func VerifyEnglishWordlist(words []string) bool {
+	return WordlistSHA256(words) == EnglishWordlistSHA256
+}
+
+// ValidateWordlist checks that words is a well-formed BIP39 wordlist: exactly
+// 2048 unique lowercase a-z words in sorted order
+func ValidateWordlist(words []string) error {
+	if len(words) != BIP39WordlistSize {
+		return fmt.Errorf("wordlist has %d words, expected %d", len(words), BIP39WordlistSize)
+	}
+
+	seen := make(map[string]int, len(words))
+	for i, word := range words {
+		if word == "" {
+			return fmt.Errorf("word %d is empty", i)
+		}
+		for _, r := range word {
+			if r < 'a' || r > 'z' {
+				return fmt.Errorf("word %d (%q) contains characters other than a-z", i, word)
+			}
+		}
+		if j, ok := seen[word]; ok {
+			return fmt.Errorf("word %d (%q) duplicates word %d", i, word, j)
+		}
+		seen[word] = i
+		if i > 0 && words[i-1] > word {
+			return fmt.Errorf("wordlist is not sorted: %q comes before %q", words[i-1], word)
+		}
+	}
+
+	return nil
+}
+
+// ValidateWordlistForLanguage is ValidateWordlist for a list of the given
+// language. Only English words are limited to sorted a-z; a list of any other
+// language must have exactly 2048 unique words without whitespace, each in
+// NFKD normal form since BIP39 compares phrases after NFKD normalization. A
+// list of unknown language, "", is held to the English rules if it is all
+// ASCII and to the general ones otherwise.
+func ValidateWordlistForLanguage(words []string, language string) error {
+	if language == "english" || (language == "" && isASCII(words)) {
+		return ValidateWordlist(words)
+	}
+
+	if len(words) != BIP39WordlistSize {
+		return fmt.Errorf("wordlist has %d words, expected %d", len(words), BIP39WordlistSize)
+	}
+
+	seen := make(map[string]int, len(words))
+	for i, word := range words {
+		if word == "" {
+			return fmt.Errorf("word %d is empty", i)
+		}
+		if strings.ContainsFunc(word, unicode.IsSpace) {
+			return fmt.Errorf("word %d (%q) contains whitespace", i, word)
+		}
+		if !norm.NFKD.IsNormalString(word) {
+			return fmt.Errorf("word %d (%q) is not NFKD normalized", i, word)
+		}
+		if j, ok := seen[word]; ok {
+			return fmt.Errorf("word %d (%q) duplicates word %d", i, word, j)
+		}
+		seen[word] = i
+	}
+
+	return nil
+}
+
+// isASCII reports whether every word consists of ASCII characters only
+func isASCII(words []string) bool {
+	for _, word := range words {
+		for i := 0; i < len(word); i++ {
+			if word[i] >= 0x80 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// WordlistReport describes the changes CleanWordlist made to a wordlist
+type WordlistReport struct {
+	DuplicatesRemoved int  // number of repeated words dropped
+	Reordered         bool // whether the remaining words had to be sorted
+}
+
+// CleanWordlist returns words with duplicates removed and sorted, together
+// with a report of what was changed. The input slice is not modified.
+func CleanWordlist(words []string) ([]string, WordlistReport) {
+	var report WordlistReport
+
+	seen := make(map[string]bool, len(words))
+	cleaned := make([]string, 0, len(words))
+	for _, word := range words {
+		if seen[word] {
+			report.DuplicatesRemoved++
+			continue
+		}
+		seen[word] = true
+		cleaned = append(cleaned, word)
+	}
+
+	if !sort.StringsAreSorted(cleaned) {
+		sort.Strings(cleaned)
+		report.Reordered = true
+	}
+
+	return cleaned, report
+}
+
+// CanonicalIndices maps the words of a phrase to their indices in
+// sortedWords, the sorted form of the wordlist. indicesToMnemonic depends on
+// wordlist order, so tools using differently ordered copies of a list give
+// the same phrase different indices; on the sorted basis they agree. A word
+// missing from sortedWords yields an ErrUnknownWord.
+func CanonicalIndices(words []string, sortedWords []string) ([]int, error) {
+	if !sort.StringsAreSorted(sortedWords) {
+		return nil, fmt.Errorf("canonical wordlist is not sorted")
+	}
+
+	indices := make([]int, len(words))
+	for i, word := range words {
+		idx := sort.SearchStrings(sortedWords, word)
+		if idx == len(sortedWords) || sortedWords[idx] != word {
+			return nil, ErrUnknownWord{Index: i, Word: word}
+		}
+		indices[i] = idx
+	}
+	return indices, nil
+}
+
+// ReadBIP39Cleaned is ReadBIP39 followed by CleanWordlist. Use ReadBIP39 to
+// get the words exactly as written, e.g. to validate an official list.
+func ReadBIP39Cleaned(r io.Reader) ([]string, WordlistReport, error) {
+	words, err := ReadBIP39(r)
+	if err != nil {
+		return nil, WordlistReport{}, err
+	}
+
+	cleaned, report := CleanWordlist(words)
+	return cleaned, report, nil
+}
+
+// wordIndexCache maps the words of the loaded wordlist back to their
+// indices. It is rebuilt whenever BIP39Words is replaced by another slice.
+var wordIndexCache struct {
+	sync.RWMutex
+	words []string
+	index map[string]int
+}
+
+// sameWordlist reports whether a and b are the same slice, not just equal
+func sameWordlist(a, b []string) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
+// WordIndex returns the index of word in the loaded wordlist. Lookups use a
+// map built once per loaded wordlist, so they cost O(1) instead of a scan of
+// all 2048 words. Replace BIP39Words rather than editing it in place, or the
+// cache goes stale.
+func WordIndex(word string) (int, bool) {
+	wordIndexCache.RLock()
+	if sameWordlist(wordIndexCache.words, BIP39Words) && wordIndexCache.index != nil {
+		idx, ok := wordIndexCache.index[word]
+		wordIndexCache.RUnlock()
+		return idx, ok
+	}
+	wordIndexCache.RUnlock()
+
+	words := BIP39Words
+	index := make(map[string]int, len(words))
+	for i := len(words) - 1; i >= 0; i-- {
+		index[words[i]] = i // iterate backwards so duplicates keep their first index
+	}
+
+	wordIndexCache.Lock()
+	wordIndexCache.words, wordIndexCache.index = words, index
+	wordIndexCache.Unlock()
+
+	idx, ok := index[word]
+	return idx, ok
+}
+
+// SuggestWords returns the words of the loaded wordlist starting with prefix,
+// sorted
+func SuggestWords(prefix string) []string {
+	var matches []string
+	for _, word := range BIP39Words {
+		if strings.HasPrefix(word, prefix) {
+			matches = append(matches, word)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// WordsContaining returns the words of the loaded wordlist containing substr
+// anywhere, sorted. The match ignores case. Together with SuggestWords it
+// covers recovery when only a fragment from the middle of a word survives.
+func WordsContaining(substr string) []string {
+	substr = strings.ToLower(substr)
+	var matches []string
+	for _, word := range BIP39Words {
+		if strings.Contains(strings.ToLower(word), substr) {
+			matches = append(matches, word)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// CompleteWord returns the word identified by prefix. BIP39 words are unique
+// in their first four letters, so any prefix of four or more letters selects
+// at most one word; shorter prefixes only complete when they are a whole word.
+func CompleteWord(prefix string) (string, bool) {
+	if _, ok := WordIndex(prefix); ok {
+		return prefix, true
+	}
+	if len([]rune(prefix)) < 4 {
+		return "", false
+	}
+
+	matches := SuggestWords(prefix)
+	if len(matches) != 1 {
+		return "", false
+	}
+	return matches[0], true
+}
+
+// NearestWord returns the word of the loaded wordlist with the smallest
+// Levenshtein distance to word, along with that distance. Ties go to the
+// earlier word in the list. Candidates that cannot beat the best distance so
+// far are skipped on length alone or abandoned part way through, so a lookup
+// rarely computes the full distance for most of the list. It returns "" and -1
+// when no wordlist is loaded.
+func NearestWord(word string) (string, int) {
+	if _, ok := WordIndex(word); ok {
+		return word, 0
+	}
+
+	target := []rune(word)
+	prev := make([]int, len(target)+1)
+	cur := make([]int, len(target)+1)
+
+	best, bestDist := "", -1
+	for _, candidate := range BIP39Words {
+		c := []rune(candidate)
+		if bestDist >= 0 && abs(len(c)-len(target)) >= bestDist {
+			continue
+		}
+
+		if d := boundedLevenshtein(target, c, bestDist, prev, cur); bestDist < 0 || d < bestDist {
+			best, bestDist = candidate, d
+		}
+	}
+
+	return best, bestDist
+}
+
+// boundedLevenshtein returns the edit distance between a and b using the
+// caller's row buffers, which must hold len(a)+1 entries. When bound is not
+// negative it gives up as soon as the distance must reach bound, returning
+// bound.
+func boundedLevenshtein(a, b []rune, bound int, prev, cur []int) int {
+	for i := range prev {
+		prev[i] = i
+	}
+
+	for j := 1; j <= len(b); j++ {
+		cur[0] = j
+		rowMin := cur[0]
+		for i := 1; i <= len(a); i++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[i] = min(prev[i]+1, cur[i-1]+1, prev[i-1]+cost)
+			rowMin = min(rowMin, cur[i])
+		}
+		if bound >= 0 && rowMin >= bound {
+			return bound
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(a)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
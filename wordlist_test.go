@@ -0,0 +1,348 @@
+package main
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestValidateWordlist(t *testing.T) {
+	words, err := readBIP39FromFile("english.txt")
+	if err != nil {
+		t.Fatalf("Error reading from file: %v", err)
+	}
+
+	if err := ValidateWordlist(words); err != nil {
+		t.Fatalf("Expected english.txt to be valid, got %v", err)
+	}
+}
+
+func TestValidateWordlist_Invalid(t *testing.T) {
+	words, err := readBIP39FromFile("english.txt")
+	if err != nil {
+		t.Fatalf("Error reading from file: %v", err)
+	}
+
+	duplicate := append([]string(nil), words...)
+	duplicate[1] = duplicate[0]
+
+	unsorted := append([]string(nil), words...)
+	unsorted[0], unsorted[1] = unsorted[1], unsorted[0]
+
+	uppercase := append([]string(nil), words...)
+	uppercase[0] = "Abandon"
+
+	tests := map[string][]string{
+		"truncated": words[:2047],
+		"duplicate": duplicate,
+		"unsorted":  unsorted,
+		"uppercase": uppercase,
+	}
+
+	for name, list := range tests {
+		if err := ValidateWordlist(list); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestValidateWordlistForLanguage(t *testing.T) {
+	// Every built-in list passes under its own language, written as a file
+	for _, name := range Languages() {
+		words, err := ReadBIP39(strings.NewReader(strings.Join(languages[name], "\n")))
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", name, err)
+		}
+		if got := DetectLanguage(words); got != name {
+			t.Errorf("%s: expected the list to be detected, got %q", name, got)
+		}
+		if err := ValidateWordlistForLanguage(words, name); err != nil {
+			t.Errorf("%s: expected no error, got %v", name, err)
+		}
+	}
+
+	spanish := wordlists.Spanish
+	composed := append([]string(nil), spanish...)
+	for i, word := range composed {
+		if nfc := norm.NFC.String(word); nfc != word {
+			composed[i] = nfc
+			break
+		}
+	}
+	duplicate := append([]string(nil), spanish...)
+	duplicate[1] = duplicate[0]
+	spaced := append([]string(nil), spanish...)
+	spaced[0] = "á baco"
+
+	tests := map[string][]string{
+		"truncated":  spanish[:2047],
+		"composed":   composed,
+		"duplicate":  duplicate,
+		"whitespace": spaced,
+	}
+
+	for name, list := range tests {
+		if err := ValidateWordlistForLanguage(list, "spanish"); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	// English keeps its a-z rules, also for a list of unknown language
+	words, err := readBIP39FromFile("english.txt")
+	if err != nil {
+		t.Fatalf("Error reading from file: %v", err)
+	}
+	uppercase := append([]string(nil), words...)
+	uppercase[0] = "Abandon"
+	for _, language := range []string{"english", ""} {
+		if err := ValidateWordlistForLanguage(uppercase, language); err == nil {
+			t.Errorf("%q: expected an error for an uppercase word", language)
+		}
+	}
+	if DetectLanguage(uppercase) != "" {
+		t.Errorf("Expected no language for a modified list")
+	}
+}
+
+func TestVerifyEnglishWordlist(t *testing.T) {
+	words, err := readBIP39FromFile("english.txt")
+	if err != nil {
+		t.Fatalf("Error reading from file: %v", err)
+	}
+	if got := WordlistSHA256(words); got != EnglishWordlistSHA256 {
+		t.Errorf("Expected hash %s, got %s", EnglishWordlistSHA256, got)
+	}
+	if !VerifyEnglishWordlist(words) {
+		t.Error("Expected english.txt to verify")
+	}
+
+	tampered := append([]string(nil), words...)
+	tampered[0] = "abandoned"
+	if VerifyEnglishWordlist(tampered) {
+		t.Error("Expected a tampered wordlist to fail verification")
+	}
+	if VerifyEnglishWordlist(words[:BIP39WordlistSize-1]) {
+		t.Error("Expected a truncated wordlist to fail verification")
+	}
+}
+
+func TestCleanWordlist(t *testing.T) {
+	tests := []struct {
+		name     string
+		words    []string
+		expected []string
+		report   WordlistReport
+	}{
+		{"clean", []string{"abandon", "ability", "able"}, []string{"abandon", "ability", "able"}, WordlistReport{}},
+		{"duplicates", []string{"abandon", "ability", "abandon", "ability"}, []string{"abandon", "ability"}, WordlistReport{DuplicatesRemoved: 2}},
+		{"unsorted", []string{"able", "abandon", "ability", "able"}, []string{"abandon", "ability", "able"}, WordlistReport{DuplicatesRemoved: 1, Reordered: true}},
+	}
+
+	for _, tt := range tests {
+		cleaned, report := CleanWordlist(tt.words)
+		if strings.Join(cleaned, " ") != strings.Join(tt.expected, " ") {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, cleaned)
+		}
+		if report != tt.report {
+			t.Errorf("%s: expected report %+v, got %+v", tt.name, tt.report, report)
+		}
+	}
+}
+
+func TestCanonicalIndices(t *testing.T) {
+	sorted := []string{"abandon", "ability", "able", "about", "above"}
+	shuffled := []string{"about", "abandon", "above", "able", "ability"}
+
+	// The same indices name different phrases in the two lists...
+	useTestWords(t, shuffled)
+	phrase := indicesToMnemonic([]int{0, 2, 4})
+
+	// ...but canonical indices identify the phrase whatever the order
+	indices, err := CanonicalIndices(phrase, sorted)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(indices) != 3 || indices[0] != 3 || indices[1] != 4 || indices[2] != 1 {
+		t.Errorf("Expected canonical indices [3 4 1], got %v", indices)
+	}
+
+	var wordErr ErrUnknownWord
+	_, err = CanonicalIndices([]string{"abandon", "zoo"}, sorted)
+	if !errors.As(err, &wordErr) || wordErr.Index != 1 {
+		t.Errorf("Expected ErrUnknownWord for word 1, got %v", err)
+	}
+	if _, err := CanonicalIndices(phrase, shuffled); err == nil {
+		t.Errorf("Expected an error for an unsorted canonical list")
+	}
+}
+
+func TestReadBIP39Cleaned(t *testing.T) {
+	raw := "able\nabandon\nability\nabandon\n"
+
+	// The raw reader keeps the list as written so validation can reject it
+	words, err := ReadBIP39(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(words) != 4 {
+		t.Errorf("Expected 4 raw words, got %d", len(words))
+	}
+
+	cleaned, report, err := ReadBIP39Cleaned(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if strings.Join(cleaned, " ") != "abandon ability able" {
+		t.Errorf("Expected cleaned list abandon ability able, got %v", cleaned)
+	}
+	if report.DuplicatesRemoved != 1 || !report.Reordered {
+		t.Errorf("Expected 1 duplicate removed and reordering, got %+v", report)
+	}
+}
+
+func TestSuggestWords(t *testing.T) {
+	useEnglishWordlist(t)
+
+	suggestions := SuggestWords("aba")
+	expected := []string{"abandon"}
+	if len(suggestions) != len(expected) || suggestions[0] != expected[0] {
+		t.Errorf("Expected %v, got %v", expected, suggestions)
+	}
+
+	suggestions = SuggestWords("ab")
+	if len(suggestions) != 10 {
+		t.Errorf("Expected 10 words starting with \"ab\", got %v", suggestions)
+	}
+	if !sort.StringsAreSorted(suggestions) {
+		t.Errorf("Expected sorted suggestions, got %v", suggestions)
+	}
+
+	if suggestions := SuggestWords("xyz"); len(suggestions) != 0 {
+		t.Errorf("Expected no suggestions, got %v", suggestions)
+	}
+}
+
+func TestWordsContaining(t *testing.T) {
+	useEnglishWordlist(t)
+
+	expected := "abandon endorse indoor random undo vendor window"
+	if got := strings.Join(WordsContaining("ndo"), " "); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+	if got := strings.Join(WordsContaining("NDO"), " "); got != expected {
+		t.Errorf("Expected a case-insensitive match %q, got %q", expected, got)
+	}
+	if matches := WordsContaining("oun"); len(matches) != 13 || !sort.StringsAreSorted(matches) {
+		t.Errorf("Expected 13 sorted words containing \"oun\", got %v", matches)
+	}
+	if matches := WordsContaining("xyz"); len(matches) != 0 {
+		t.Errorf("Expected no matches, got %v", matches)
+	}
+}
+
+func TestCompleteWord(t *testing.T) {
+	useEnglishWordlist(t)
+
+	tests := []struct {
+		prefix   string
+		expected string
+		ok       bool
+	}{
+		{"aban", "abandon", true},
+		{"abando", "abandon", true},
+		{"abandon", "abandon", true},
+		{"act", "act", true},
+		{"ab", "", false},
+		{"abax", "", false},
+	}
+
+	for _, tt := range tests {
+		word, ok := CompleteWord(tt.prefix)
+		if word != tt.expected || ok != tt.ok {
+			t.Errorf("CompleteWord(%q): expected (%q, %v), got (%q, %v)", tt.prefix, tt.expected, tt.ok, word, ok)
+		}
+	}
+}
+
+func TestNearestWord(t *testing.T) {
+	useEnglishWordlist(t)
+
+	tests := []struct {
+		word     string
+		expected string
+		distance int
+	}{
+		{"abandon", "abandon", 0},
+		{"abandom", "abandon", 1},
+		{"abandn", "abandon", 1},
+		{"aabandon", "abandon", 1},
+		{"zooo", "zoo", 1},
+		{"mothr", "mother", 1},
+	}
+
+	for _, tt := range tests {
+		word, distance := NearestWord(tt.word)
+		if word != tt.expected || distance != tt.distance {
+			t.Errorf("NearestWord(%q): expected (%q, %d), got (%q, %d)", tt.word, tt.expected, tt.distance, word, distance)
+		}
+	}
+}
+
+func TestBoundedLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+	}
+
+	for _, tt := range tests {
+		a, b := []rune(tt.a), []rune(tt.b)
+		prev, cur := make([]int, len(a)+1), make([]int, len(a)+1)
+		if d := boundedLevenshtein(a, b, -1, prev, cur); d != tt.expected {
+			t.Errorf("distance(%q, %q): expected %d, got %d", tt.a, tt.b, tt.expected, d)
+		}
+	}
+}
+
+func TestWordIndex(t *testing.T) {
+	useEnglishWordlist(t)
+
+	tests := map[string]int{"abandon": 0, "about": 3, "mother": 1153, "zoo": 2047}
+	for word, expected := range tests {
+		if idx, ok := WordIndex(word); !ok || idx != expected {
+			t.Errorf("%s: expected index %d, got %d (found %v)", word, expected, idx, ok)
+		}
+	}
+	if _, ok := WordIndex("brokenn"); ok {
+		t.Errorf("Expected brokenn not to be found")
+	}
+
+	// Loading another list replaces the cached lookup
+	BIP39Words = []string{"zoo", "abandon", "zoo"}
+	if idx, ok := WordIndex("abandon"); !ok || idx != 1 {
+		t.Errorf("Expected abandon at index 1 of the new list, got %d (found %v)", idx, ok)
+	}
+	if idx, _ := WordIndex("zoo"); idx != 0 {
+		t.Errorf("Expected a duplicate word to keep its first index, got %d", idx)
+	}
+	if _, ok := WordIndex("about"); ok {
+		t.Errorf("Expected about not to be in the new list")
+	}
+}
+
+func BenchmarkWordIndex(b *testing.B) {
+	words := useEnglishWordlist(b)
+
+	for i := 0; i < b.N; i++ {
+		WordIndex(words[i%len(words)])
+	}
+}
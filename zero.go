@@ -0,0 +1,15 @@
+package main
+
+// Zero overwrites b with zeros so a seed or key does not linger in memory
+// once it is no longer needed.
+//
+// This is best effort only. Go gives no control over copies the runtime or
+// the garbage collector make, strings such as the mnemonic itself are
+// immutable and cannot be wiped, and libraries may keep their own copies of
+// the bytes. Zeroing narrows the window in which secrets sit in RAM; it does
+// not close it.
+func Zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,17 @@
+package main
+
+import "testing"
+
+func TestZero(t *testing.T) {
+	b := []byte{1, 2, 3, 4}
+	Zero(b)
+	for i, v := range b {
+		if v != 0 {
+			t.Errorf("Expected byte %d to be zero, got %d", i, v)
+		}
+	}
+
+	// Zeroing an empty or nil buffer is a no-op
+	Zero(nil)
+	Zero([]byte{})
+}